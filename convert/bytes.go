@@ -0,0 +1,69 @@
+package convert
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+type bytesLike interface {
+	~[]byte | ~string
+}
+
+// ByteEncoding turns bytes into text and back. *base64.Encoding and *base32.Encoding implement it.
+type ByteEncoding interface {
+	EncodeToString(src []byte) string
+	DecodeString(s string) ([]byte, error)
+}
+
+var (
+	Hex          ByteEncoding = hexEncoding{}
+	Base64       ByteEncoding = base64.StdEncoding
+	RawBase64    ByteEncoding = base64.RawStdEncoding
+	Base64URL    ByteEncoding = base64.URLEncoding
+	RawBase64URL ByteEncoding = base64.RawURLEncoding
+	Base32       ByteEncoding = base32.StdEncoding
+	RawBase32    ByteEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+type hexEncoding struct{}
+
+func (hexEncoding) EncodeToString(src []byte) string {
+	return hex.EncodeToString(src)
+}
+
+func (hexEncoding) DecodeString(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
+/*
+Returns a converter writing bytes as text in the given encoding.
+The bytes may be held in a []byte or in a string, the latter
+being usable as an element of a collection.
+*/
+func EncodeBytes[T bytesLike](encoding ByteEncoding) func(el T) string {
+	return func(el T) string {
+		return encoding.EncodeToString([]byte(el))
+	}
+}
+
+/*
+Returns a parser of text in the given encoding
+back into bytes held in a []byte or in a string.
+*/
+func DecodeBytes[T bytesLike](encoding ByteEncoding) func(el string) (T, error) {
+	return func(el string) (T, error) {
+		newEl, err := encoding.DecodeString(el)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return T(newEl), nil
+	}
+}
+
+// Returns a codec between bytes and their text in the given encoding.
+func BytesCodec[T bytesLike](encoding ByteEncoding) Codec[T, string] {
+	return NewCodec(infallible(EncodeBytes[T](encoding)), DecodeBytes[T](encoding))
+}
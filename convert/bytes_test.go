@@ -0,0 +1,88 @@
+package convert_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/kdl-dev/gofunc"
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBytes(t *testing.T) {
+	input := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	tests := []struct {
+		name     string
+		encoding convert.ByteEncoding
+		expected string
+	}{
+		{
+			name:     "hex",
+			encoding: convert.Hex,
+			expected: "deadbeef",
+		},
+		{
+			name:     "base64",
+			encoding: convert.Base64,
+			expected: "3q2+7w==",
+		},
+		{
+			name:     "raw base64",
+			encoding: convert.RawBase64,
+			expected: "3q2+7w",
+		},
+		{
+			name:     "base64 url",
+			encoding: convert.Base64URL,
+			expected: "3q2-7w==",
+		},
+		{
+			name:     "raw base64 url",
+			encoding: convert.RawBase64URL,
+			expected: "3q2-7w",
+		},
+		{
+			name:     "base32",
+			encoding: convert.Base32,
+			expected: "32W353Y=",
+		},
+		{
+			name:     "raw base32",
+			encoding: convert.RawBase32,
+			expected: "32W353Y",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		require.Equal(t, test.expected, convert.EncodeBytes[[]byte](test.encoding)(input))
+
+		decoded, err := convert.DecodeBytes[[]byte](test.encoding)(test.expected)
+		require.NoError(t, err)
+		require.Equal(t, input, decoded)
+
+		_, err = convert.DecodeBytes[[]byte](test.encoding)("!")
+		require.Error(t, err)
+	}
+}
+
+func TestEncodeBytesSlice(t *testing.T) {
+	slice := convert.New([][]byte{{1, 2}, {255}}, convert.EncodeBytes[[]byte](convert.Hex))
+	require.Equal(t, []string{"0102", "ff"}, slice)
+
+	hashes := gofunc.New([]string{"a", "b"}).
+		Map(func(el string) string { sum := sha256.Sum256([]byte(el)); return string(sum[:4]) })
+	require.Equal(t, "ca978112,3e23e816,", hashes.ToString(func(el string) string {
+		return convert.EncodeBytes[string](convert.Hex)(el) + ","
+	}))
+
+	decoded, err := convert.TryNew([]string{"0102", "zz"}, convert.DecodeBytes[[]byte](convert.Hex))
+	require.Error(t, err)
+	require.Equal(t, [][]byte{{1, 2}, nil}, decoded)
+}
+
+func TestBytesCodec(t *testing.T) {
+	codec := convert.BytesCodec[string](convert.RawBase64URL)
+	require.NoError(t, convert.RoundTrip(codec, []string{"", "\x00\xff", "token"}))
+}
@@ -0,0 +1,158 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// Codec converts values of type A to type B and back.
+type Codec[A, B any] struct {
+	encode func(el A) (B, error)
+	decode func(el B) (A, error)
+}
+
+func NewCodec[A, B any](encode func(el A) (B, error), decode func(el B) (A, error)) Codec[A, B] {
+	return Codec[A, B]{encode: encode, decode: decode}
+}
+
+func (c Codec[A, B]) Encode(el A) (B, error) {
+	return c.encode(el)
+}
+
+func (c Codec[A, B]) Decode(el B) (A, error) {
+	return c.decode(el)
+}
+
+// Returns the codec converting in the opposite direction.
+func (c Codec[A, B]) Inverse() Codec[B, A] {
+	return Codec[B, A]{encode: c.decode, decode: c.encode}
+}
+
+/*
+Returns the codec encoding with first and then with second,
+and decoding in the reverse order.
+*/
+func Then[A, B, C any](first Codec[A, B], second Codec[B, C]) Codec[A, C] {
+	return Codec[A, C]{
+		encode: func(el A) (C, error) {
+			var zero C
+
+			middle, err := first.encode(el)
+			if err != nil {
+				return zero, err
+			}
+
+			return second.encode(middle)
+		},
+		decode: func(el C) (A, error) {
+			var zero A
+
+			middle, err := second.decode(el)
+			if err != nil {
+				return zero, err
+			}
+
+			return first.decode(middle)
+		},
+	}
+}
+
+/*
+Registers both directions of the codec in the registry.
+*/
+func RegisterCodec[A, B any](r *Registry, codec Codec[A, B]) {
+	RegisterTry(r, codec.encode)
+	RegisterTry(r, codec.decode)
+}
+
+/*
+Checks that decoding the encoding of every element of slice gives the
+element back. Elements are compared with their Equal method when they
+have one, as time.Time does, and with == otherwise. The elements that
+fail are reported as *IndexError values joined into the returned error.
+*/
+func RoundTrip[A comparable, B any](codec Codec[A, B], slice []A) error {
+	var errs []error
+
+	for i, el := range slice {
+		encoded, err := codec.encode(el)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: fmt.Errorf("encode: %w", err)})
+			continue
+		}
+
+		decoded, err := codec.decode(encoded)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: fmt.Errorf("decode: %w", err)})
+			continue
+		}
+
+		if !equal(el, decoded) {
+			errs = append(errs, &IndexError{Index: i, Err: fmt.Errorf("%v round-trips to %v", el, decoded)})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func IntCodec[T ints]() Codec[T, string] {
+	return NewCodec(infallible(IntToString[T]), ParseInt[T])
+}
+
+func UintCodec[T uints]() Codec[T, string] {
+	return NewCodec(infallible(IntToString[T]), ParseUint[T])
+}
+
+// FloatCodec writes floats with the fewest digits that decode back to the same value.
+func FloatCodec[T floats]() Codec[T, string] {
+	return NewCodec(infallible(FormatFloat[T](-1, 'g')), ParseFloat[T])
+}
+
+func ComplexCodec[T complex]() Codec[T, string] {
+	return NewCodec(infallible(ComplexToString[T]), ParseComplex[T])
+}
+
+func BoolCodec() Codec[bool, string] {
+	return NewCodec(infallible(BoolToString), ParseBool)
+}
+
+// RuneCodec converts a rune to the string holding just that rune.
+func RuneCodec() Codec[rune, string] {
+	return NewCodec(infallible(RuneToRawString), func(el string) (rune, error) {
+		newEl, size := utf8.DecodeRuneInString(el)
+		if size == 0 || size != len(el) || newEl == utf8.RuneError && size == 1 {
+			return 0, fmt.Errorf("convert: %q is not a single rune", el)
+		}
+
+		return newEl, nil
+	})
+}
+
+// TimeCodec converts times to strings and back with the given layout, such as time.RFC3339Nano.
+func TimeCodec(layout string) Codec[time.Time, string] {
+	return NewCodec(
+		func(el time.Time) (string, error) { return el.Format(layout), nil },
+		func(el string) (time.Time, error) { return time.Parse(layout, el) },
+	)
+}
+
+// DurationCodec converts durations to strings such as "1h2m3.5s" and back.
+func DurationCodec() Codec[time.Duration, string] {
+	return NewCodec(infallible(time.Duration.String), time.ParseDuration)
+}
+
+func infallible[A, B any](convertFunc func(el A) B) func(el A) (B, error) {
+	return func(el A) (B, error) {
+		return convertFunc(el), nil
+	}
+}
+
+func equal[A comparable](a, b A) bool {
+	if equaler, ok := any(a).(interface{ Equal(A) bool }); ok {
+		return equaler.Equal(b)
+	}
+
+	return a == b
+}
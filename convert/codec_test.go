@@ -0,0 +1,97 @@
+package convert_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	require.NoError(t, convert.RoundTrip(convert.IntCodec[int8](), []int8{math.MinInt8, 0, math.MaxInt8}))
+	require.NoError(t, convert.RoundTrip(convert.UintCodec[uint64](), []uint64{0, math.MaxUint64}))
+	require.NoError(t, convert.RoundTrip(convert.FloatCodec[float64](), []float64{0.1, -1e300, math.SmallestNonzeroFloat64}))
+	require.NoError(t, convert.RoundTrip(convert.FloatCodec[float32](), []float32{0.1, math.MaxFloat32}))
+	require.NoError(t, convert.RoundTrip(convert.ComplexCodec[complex128](), []complex128{1 + 5i, -0.1i}))
+	require.NoError(t, convert.RoundTrip(convert.BoolCodec(), []bool{true, false}))
+	require.NoError(t, convert.RoundTrip(convert.RuneCodec(), []rune{'a', 'ñ', '世'}))
+	require.NoError(t, convert.RoundTrip(convert.DurationCodec(), []time.Duration{0, 90 * time.Minute, -time.Nanosecond}))
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	times := []time.Time{time.Date(2023, 5, 1, 12, 30, 0, 123, moscow), time.Now()}
+	require.NoError(t, convert.RoundTrip(convert.TimeCodec(time.RFC3339Nano), times))
+}
+
+func TestCodecRoundTripFailure(t *testing.T) {
+	lossy := convert.NewCodec(
+		func(el float64) (string, error) { return convert.FloatToString(el), nil },
+		convert.ParseFloat[float64],
+	)
+
+	err := convert.RoundTrip(lossy, []float64{0.5, 0.1234567})
+
+	var indexErr *convert.IndexError
+	require.True(t, errors.As(err, &indexErr))
+	require.Equal(t, 1, indexErr.Index)
+
+	err = convert.RoundTrip(convert.TimeCodec(time.DateOnly), []time.Time{time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)})
+	require.Error(t, err)
+}
+
+func TestCodecDecode(t *testing.T) {
+	_, err := convert.RuneCodec().Decode("ab")
+	require.Error(t, err)
+
+	_, err = convert.RuneCodec().Decode("")
+	require.Error(t, err)
+
+	_, err = convert.IntCodec[int8]().Decode("128")
+	require.Error(t, err)
+
+	d, err := convert.DurationCodec().Decode("1m30s")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, d)
+}
+
+func TestThen(t *testing.T) {
+	upper := convert.NewCodec(
+		func(el string) (string, error) { return strings.ToUpper(el), nil },
+		func(el string) (string, error) { return strings.ToLower(el), nil },
+	)
+	codec := convert.Then(convert.BoolCodec(), upper)
+
+	encoded, err := codec.Encode(true)
+	require.NoError(t, err)
+	require.Equal(t, "TRUE", encoded)
+
+	decoded, err := codec.Decode("FALSE")
+	require.NoError(t, err)
+	require.False(t, decoded)
+
+	_, err = codec.Decode("NO")
+	require.Error(t, err)
+
+	require.NoError(t, convert.RoundTrip(codec, []bool{true, false}))
+
+	inverse := convert.BoolCodec().Inverse()
+	parsed, err := inverse.Encode("true")
+	require.NoError(t, err)
+	require.True(t, parsed)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	r := convert.NewRegistry()
+	convert.RegisterCodec(r, convert.DurationCodec())
+
+	strSlice, err := convert.ToWith[string](r, []time.Duration{time.Second})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1s"}, strSlice)
+
+	durations, err := convert.ToWith[time.Duration](r, []string{"2ms"})
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{2 * time.Millisecond}, durations)
+}
@@ -1,7 +1,11 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 type ints interface {
@@ -20,7 +24,7 @@ type complex interface {
 	complex64 | complex128
 }
 
-func New[T, V comparable](slice []T, convertFunc func(el T) V) []V {
+func New[T, V any](slice []T, convertFunc func(el T) V) []V {
 	if slice == nil || convertFunc == nil {
 		return nil
 	}
@@ -34,6 +38,47 @@ func New[T, V comparable](slice []T, convertFunc func(el T) V) []V {
 	return newSlice
 }
 
+// IndexError records a failure to convert the element at Index of a slice.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("convert: index %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Converts a slice of type T to a slice of type V with a conversion
+that can fail. Every element is converted; the elements that fail
+are left as zero values and reported as *IndexError values joined
+into the returned error.
+*/
+func TryNew[T, V any](slice []T, convertFunc func(el T) (V, error)) ([]V, error) {
+	if slice == nil || convertFunc == nil {
+		return nil, nil
+	}
+
+	newSlice := make([]V, len(slice))
+	var errs []error
+
+	for i := 0; i < len(slice); i++ {
+		newEl, err := convertFunc(slice[i])
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+
+		newSlice[i] = newEl
+	}
+
+	return newSlice, errors.Join(errs...)
+}
+
 func IntToString[T ints | uints](el T) string {
 	return fmt.Sprintf("%d", el)
 }
@@ -54,52 +99,130 @@ func ComplexToString[T complex](el T) string {
 	return fmt.Sprintf("%g", el)
 }
 
+func RuneToRawString(el rune) string {
+	return string(el)
+}
+
 /*
-func StringToInt[T ints](el string) T {
-	newEl, err := strconv.ParseInt(el, 10, 64)
-	if err != nil {
-		panic(err)
+Returns a float formatter using strconv.FormatFloat with the given
+format ('f', 'e', 'g', ...) and precision. A precision of -1 uses
+the smallest number of digits that represents the value exactly.
+*/
+func FormatFloat[T floats](prec int, format byte) func(el T) string {
+	return func(el T) string {
+		return strconv.FormatFloat(float64(el), format, prec, bitSize[T]())
+	}
+}
+
+/*
+Returns an integer formatter writing numbers in the given base (2 to 36),
+padded to at least width characters. Padding uses zeros placed after
+the sign when zeroPad is set, and leading spaces otherwise.
+It panics if base is out of range, rather than the formatter
+panicking the first time it is used.
+*/
+func FormatInt[T ints | uints](base, width int, zeroPad bool) func(el T) string {
+	if base < 2 || base > 36 {
+		panic(fmt.Sprintf("convert: FormatInt: base %d is not in the range 2 to 36", base))
 	}
 
-	return T(newEl)
+	return func(el T) string {
+		var sign, digits string
+
+		if el < 0 {
+			sign, digits = "-", strconv.FormatInt(int64(el), base)[1:]
+		} else {
+			digits = strconv.FormatUint(uint64(el), base)
+		}
+
+		padding := width - len(sign) - len(digits)
+		if padding <= 0 {
+			return sign + digits
+		}
+
+		if zeroPad {
+			return sign + strings.Repeat("0", padding) + digits
+		}
+
+		return strings.Repeat(" ", padding) + sign + digits
+	}
 }
 
-func StringToUInt[T uints](el string) T {
-	newEl, err := strconv.ParseUint(el, 10, 64)
-	if err != nil {
-		panic(err)
+/*
+Returns a formatter applying the given fmt verb,
+such as "%v", "%x" or "%08.3f", to every element.
+*/
+func Sprintf[T any](verb string) func(el T) string {
+	return func(el T) string {
+		return fmt.Sprintf(verb, el)
 	}
+}
 
-	return T(newEl)
+func ParseInt[T ints](el string) (T, error) {
+	return ParseIntBase[T](10)(el)
 }
 
-func StringToFloat[T floats](el string) T {
-	newEl, err := strconv.ParseFloat(el, 64)
-	if err != nil {
-		panic(err)
+/*
+Returns a parser of integers written in the given base. Base 0 means
+the base is implied by the string's prefix, as in strconv.ParseInt.
+Values that don't fit into T produce a strconv.ErrRange error.
+*/
+func ParseIntBase[T ints](base int) func(el string) (T, error) {
+	return func(el string) (T, error) {
+		newEl, err := strconv.ParseInt(el, base, bitSize[T]())
+		if err != nil {
+			return 0, err
+		}
+
+		return T(newEl), nil
 	}
+}
 
-	return T(newEl)
+func ParseUint[T uints](el string) (T, error) {
+	return ParseUintBase[T](10)(el)
 }
 
-func StringToBool(el string) bool {
-	newEl, err := strconv.ParseBool(el)
+/*
+Returns a parser of unsigned integers written in the given base.
+Base 0 means the base is implied by the string's prefix, as in
+strconv.ParseUint. Values that don't fit into T produce
+a strconv.ErrRange error.
+*/
+func ParseUintBase[T uints](base int) func(el string) (T, error) {
+	return func(el string) (T, error) {
+		newEl, err := strconv.ParseUint(el, base, bitSize[T]())
+		if err != nil {
+			return 0, err
+		}
+
+		return T(newEl), nil
+	}
+}
+
+func ParseFloat[T floats](el string) (T, error) {
+	newEl, err := strconv.ParseFloat(el, bitSize[T]())
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 
-	return newEl
+	return T(newEl), nil
 }
 
-func StringToSliceRune[T complex](el string) []rune {
-	return []rune(el)
+func ParseBool(el string) (bool, error) {
+	return strconv.ParseBool(el)
 }
 
-func StringToComplex[T complex](el string) T {
-	newEl, err := strconv.ParseComplex(el, 128)
+func ParseComplex[T complex](el string) (T, error) {
+	newEl, err := strconv.ParseComplex(el, bitSize[T]())
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 
-	return T(newEl)
-}*/
+	return T(newEl), nil
+}
+
+func bitSize[T ints | uints | floats | complex]() int {
+	var el T
+
+	return reflect.TypeOf(el).Bits()
+}
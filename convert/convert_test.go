@@ -1,6 +1,7 @@
 package convert_test
 
 import (
+	"errors"
 	"strconv"
 	"testing"
 
@@ -103,3 +104,215 @@ func TestComplexToString(t *testing.T) {
 
 	require.Equal(t, test.expected, actual)
 }
+
+func TestTryNew(t *testing.T) {
+	slice, err := convert.TryNew([]string{"1", "x", "3", "300"}, convert.ParseInt[int8])
+	require.Equal(t, []int8{1, 0, 3, 0}, slice)
+
+	var indexErr *convert.IndexError
+	require.True(t, errors.As(err, &indexErr))
+	require.Equal(t, 1, indexErr.Index)
+	require.Contains(t, err.Error(), "index 3")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	slice, err = convert.TryNew([]string{"1", "2"}, convert.ParseInt[int8])
+	require.NoError(t, err)
+	require.Equal(t, []int8{1, 2}, slice)
+
+	slice, err = convert.TryNew(nil, convert.ParseInt[int8])
+	require.NoError(t, err)
+	require.Nil(t, slice)
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		parse    func(string) (int8, error)
+		expected int8
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			input:    "-128",
+			parse:    convert.ParseInt[int8],
+			expected: -128,
+		},
+		{
+			name:    "test2",
+			input:   "128",
+			parse:   convert.ParseInt[int8],
+			isError: true,
+		},
+		{
+			name:     "test3",
+			input:    "7f",
+			parse:    convert.ParseIntBase[int8](16),
+			expected: 127,
+		},
+		{
+			name:     "test4",
+			input:    "0b101",
+			parse:    convert.ParseIntBase[int8](0),
+			expected: 5,
+		},
+		{
+			name:    "test5",
+			input:   "abc",
+			parse:   convert.ParseInt[int8],
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		actual, err := test.parse(test.input)
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, actual)
+	}
+}
+
+func TestParseUint(t *testing.T) {
+	actual, err := convert.ParseUint[uint16]("65535")
+	require.NoError(t, err)
+	require.Equal(t, uint16(65535), actual)
+
+	_, err = convert.ParseUint[uint16]("65536")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = convert.ParseUint[uint]("-1")
+	require.Error(t, err)
+
+	actual, err = convert.ParseUintBase[uint16](2)("1111")
+	require.NoError(t, err)
+	require.Equal(t, uint16(15), actual)
+}
+
+func TestParseFloat(t *testing.T) {
+	actual, err := convert.ParseFloat[float64]("2.5")
+	require.NoError(t, err)
+	require.Equal(t, 2.5, actual)
+
+	_, err = convert.ParseFloat[float32]("1e39")
+	require.ErrorIs(t, err, strconv.ErrRange)
+}
+
+func TestParseBool(t *testing.T) {
+	actual, err := convert.ParseBool("true")
+	require.NoError(t, err)
+	require.True(t, actual)
+
+	_, err = convert.ParseBool("yes")
+	require.Error(t, err)
+}
+
+func TestParseComplex(t *testing.T) {
+	actual, err := convert.ParseComplex[complex128]("1+5i")
+	require.NoError(t, err)
+	require.Equal(t, 1+5i, actual)
+
+	_, err = convert.ParseComplex[complex64]("x")
+	require.Error(t, err)
+}
+
+func TestRuneToRawString(t *testing.T) {
+	test := Test[rune, string]{
+		input:    65,
+		expected: "A",
+	}
+	actual := convert.RuneToRawString(test.input)
+
+	require.Equal(t, test.expected, actual)
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   func(float64) string
+		input    float64
+		expected string
+	}{
+		{
+			name:     "test1",
+			format:   convert.FormatFloat[float64](2, 'f'),
+			input:    3.14159,
+			expected: "3.14",
+		},
+		{
+			name:     "test2",
+			format:   convert.FormatFloat[float64](-1, 'g'),
+			input:    0.1,
+			expected: "0.1",
+		},
+		{
+			name:     "test3",
+			format:   convert.FormatFloat[float64](3, 'e'),
+			input:    1234.5,
+			expected: "1.234e+03",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		require.Equal(t, test.expected, test.format(test.input))
+	}
+}
+
+func TestFormatInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   func(int) string
+		input    int
+		expected string
+	}{
+		{
+			name:     "test1",
+			format:   convert.FormatInt[int](16, 0, false),
+			input:    255,
+			expected: "ff",
+		},
+		{
+			name:     "test2",
+			format:   convert.FormatInt[int](10, 5, true),
+			input:    -42,
+			expected: "-0042",
+		},
+		{
+			name:     "test3",
+			format:   convert.FormatInt[int](2, 6, false),
+			input:    5,
+			expected: "   101",
+		},
+		{
+			name:     "test4",
+			format:   convert.FormatInt[int](10, 2, true),
+			input:    12345,
+			expected: "12345",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		require.Equal(t, test.expected, test.format(test.input))
+	}
+
+	require.Equal(t, "0000ff", convert.FormatInt[uint8](16, 6, true)(255))
+
+	require.PanicsWithValue(t, "convert: FormatInt: base 37 is not in the range 2 to 36", func() {
+		convert.FormatInt[int](37, 0, false)
+	})
+	require.Panics(t, func() { convert.FormatInt[int](1, 0, false) })
+}
+
+func TestSprintf(t *testing.T) {
+	slice := convert.New([]float64{1.5, 2.25}, convert.Sprintf[float64]("%06.2f"))
+	require.Equal(t, []string{"001.50", "002.25"}, slice)
+
+	require.Equal(t, "0x1f", convert.Sprintf[int]("%#x")(31))
+}
@@ -0,0 +1,185 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteUnits selects the units HumanBytes writes sizes in.
+type ByteUnits int
+
+const (
+	// SI units are powers of 1000: kB, MB, GB, ...
+	SI ByteUnits = iota
+	// IEC units are powers of 1024: KiB, MiB, GiB, ...
+	IEC
+)
+
+var (
+	siByteUnits     = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	iecByteUnits    = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	compactSuffixes = []string{"", "k", "M", "B", "T"}
+	ordinalSuffixes = []string{"th", "st", "nd", "rd"}
+)
+
+/*
+Returns a formatter of sizes in bytes, such as "999 B", "1.2 MB"
+or "1.2 MiB", with one decimal for every unit but bytes. Infinities
+and NaN are written unchanged as "+Inf", "-Inf" and "NaN".
+*/
+func HumanBytes[T ints | uints | floats](units ByteUnits) func(el T) string {
+	base, names := 1000.0, siByteUnits
+	if units == IEC {
+		base, names = 1024.0, iecByteUnits
+	}
+
+	return func(el T) string {
+		value := float64(el)
+		sign := ""
+
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return formatNumber(el)
+		}
+
+		if value < 0 {
+			sign, value = "-", -value
+		}
+
+		if value < base {
+			return sign + strconv.FormatFloat(value, 'f', -1, 64) + " " + names[0]
+		}
+
+		exp := 0
+		for value >= base && exp < len(names)-1 {
+			value /= base
+			exp++
+		}
+
+		// Rounding may carry over to the next unit, as in 999.96 kB.
+		if math.Round(value*10)/10 >= base && exp < len(names)-1 {
+			value /= base
+			exp++
+		}
+
+		return sign + strconv.FormatFloat(value, 'f', 1, 64) + " " + names[exp]
+	}
+}
+
+/*
+Returns a formatter of numbers with their integer digits grouped
+by three, such as "1,234,567" or "-1 234.5" for the separators
+"," and " ". Floats are written with as few decimals as possible,
+and infinities and NaN are written unchanged as "+Inf", "-Inf" and "NaN".
+*/
+func GroupDigits[T ints | uints | floats](sep string) func(el T) string {
+	return func(el T) string {
+		text := formatNumber(el)
+		sign := ""
+
+		if math.IsInf(float64(el), 0) || math.IsNaN(float64(el)) {
+			return text
+		}
+
+		if strings.HasPrefix(text, "-") {
+			sign, text = "-", text[1:]
+		}
+
+		intPart, fracPart, hasFrac := strings.Cut(text, ".")
+
+		var sb strings.Builder
+		sb.WriteString(sign)
+
+		for i, digit := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				sb.WriteString(sep)
+			}
+
+			sb.WriteRune(digit)
+		}
+
+		if hasFrac {
+			sb.WriteString("." + fracPart)
+		}
+
+		return sb.String()
+	}
+}
+
+/*
+Returns a formatter of ratios as percentages with prec decimals,
+so 0.425 is written as "42.5%" with a precision of 1.
+*/
+func Percent[T floats](prec int) func(el T) string {
+	return func(el T) string {
+		return strconv.FormatFloat(float64(el)*100, 'f', prec, 64) + "%"
+	}
+}
+
+// Writes an integer with its English ordinal suffix, such as "1st", "12th" or "23rd".
+func Ordinal[T ints | uints](el T) string {
+	text := IntToString(el)
+
+	// The last two digits are enough to choose the suffix.
+	lastTwo := el % 100
+	if lastTwo < 0 {
+		lastTwo = -lastTwo
+	}
+
+	if lastTwo >= 11 && lastTwo <= 13 || lastTwo%10 > 3 {
+		return text + ordinalSuffixes[0]
+	}
+
+	return text + ordinalSuffixes[lastTwo%10]
+}
+
+/*
+Returns a formatter of numbers in compact notation with at most prec
+decimals, such as "950", "1.2k", "3.4M", "5B" or "6.7T". Infinities
+and NaN are written unchanged as "+Inf", "-Inf" and "NaN".
+*/
+func Compact[T ints | uints | floats](prec int) func(el T) string {
+	return func(el T) string {
+		value := float64(el)
+		sign := ""
+
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return formatNumber(el)
+		}
+
+		if value < 0 {
+			sign, value = "-", -value
+		}
+
+		exp := 0
+		for value >= 1000 && exp < len(compactSuffixes)-1 {
+			value /= 1000
+			exp++
+		}
+
+		scale := math.Pow(10, float64(prec))
+		if math.Round(value*scale)/scale >= 1000 && exp < len(compactSuffixes)-1 {
+			value /= 1000
+			exp++
+		}
+
+		text := strconv.FormatFloat(value, 'f', prec, 64)
+		if strings.Contains(text, ".") {
+			text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+		}
+
+		return sign + text + compactSuffixes[exp]
+	}
+}
+
+func formatNumber[T ints | uints | floats](el T) string {
+	switch v := any(el).(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(el)
+}
@@ -0,0 +1,95 @@
+package convert_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kdl-dev/gofunc"
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHumanBytes(t *testing.T) {
+	tests := []struct {
+		input    int64
+		units    convert.ByteUnits
+		expected string
+	}{
+		{0, convert.SI, "0 B"},
+		{999, convert.SI, "999 B"},
+		{1000, convert.SI, "1.0 kB"},
+		{1234567, convert.SI, "1.2 MB"},
+		{999960, convert.SI, "1.0 MB"},
+		{1023, convert.IEC, "1023 B"},
+		{1024, convert.IEC, "1.0 KiB"},
+		{1258291, convert.IEC, "1.2 MiB"},
+		{-2048, convert.IEC, "-2.0 KiB"},
+	}
+
+	for _, test := range tests {
+		t.Log(test.expected)
+		require.Equal(t, test.expected, convert.HumanBytes[int64](test.units)(test.input))
+	}
+
+	require.Equal(t, "16.0 EiB", convert.HumanBytes[uint64](convert.IEC)(1<<64-1))
+	require.Equal(t, "+Inf", convert.HumanBytes[float64](convert.SI)(math.Inf(1)))
+	require.Equal(t, "-Inf", convert.HumanBytes[float32](convert.SI)(float32(math.Inf(-1))))
+	require.Equal(t, "NaN", convert.HumanBytes[float64](convert.IEC)(math.NaN()))
+}
+
+func TestGroupDigits(t *testing.T) {
+	require.Equal(t, "1,234,567", convert.GroupDigits[int](",")(1234567))
+	require.Equal(t, "-123,456", convert.GroupDigits[int32](",")(-123456))
+	require.Equal(t, "999", convert.GroupDigits[uint](",")(999))
+	require.Equal(t, "0", convert.GroupDigits[int](",")(0))
+	require.Equal(t, "1 234.5", convert.GroupDigits[float64](" ")(1234.5))
+	require.Equal(t, "-12_345.25", convert.GroupDigits[float32]("_")(-12345.25))
+	require.Equal(t, "+Inf", convert.GroupDigits[float64](",")(math.Inf(1)))
+	require.Equal(t, "-Inf", convert.GroupDigits[float32](",")(float32(math.Inf(-1))))
+	require.Equal(t, "NaN", convert.GroupDigits[float64](",")(math.NaN()))
+}
+
+func TestPercent(t *testing.T) {
+	require.Equal(t, "42.5%", convert.Percent[float64](1)(0.425))
+	require.Equal(t, "100%", convert.Percent[float64](0)(1))
+	require.Equal(t, "-3.14%", convert.Percent[float32](2)(-0.0314))
+}
+
+func TestOrdinal(t *testing.T) {
+	slice := convert.New([]int{0, 1, 2, 3, 4, 11, 12, 13, 21, 22, 23, 101, 111, 112, -1, -12}, convert.Ordinal[int])
+	require.Equal(t, []string{
+		"0th", "1st", "2nd", "3rd", "4th", "11th", "12th", "13th",
+		"21st", "22nd", "23rd", "101st", "111th", "112th", "-1st", "-12th",
+	}, slice)
+
+	require.Equal(t, "255th", convert.Ordinal[uint8](255))
+}
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		input    float64
+		prec     int
+		expected string
+	}{
+		{950, 1, "950"},
+		{1000, 1, "1k"},
+		{1234, 1, "1.2k"},
+		{3_400_000, 1, "3.4M"},
+		{5_000_000_000, 1, "5B"},
+		{6_700_000_000_000, 1, "6.7T"},
+		{999_960, 1, "1M"},
+		{-1500, 2, "-1.5k"},
+		{12.345, 2, "12.35"},
+		{math.Inf(1), 1, "+Inf"},
+		{math.Inf(-1), 1, "-Inf"},
+		{math.NaN(), 1, "NaN"},
+	}
+
+	for _, test := range tests {
+		t.Log(test.expected)
+		require.Equal(t, test.expected, convert.Compact[float64](test.prec)(test.input))
+	}
+
+	sizes := gofunc.New([]int{1500, 2_000_000}).ToString(convert.Compact[int](1))
+	require.Equal(t, "1.5k2M", sizes)
+}
@@ -0,0 +1,160 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrOverflow = errors.New("value out of range")
+	ErrNaN      = errors.New("value is NaN")
+	ErrInf      = errors.New("value is infinite")
+)
+
+// RoundingMode tells FloatToInt how to drop the fractional part of a float.
+type RoundingMode int
+
+const (
+	// RoundTowardZero drops the fractional part, like a Go conversion does.
+	RoundTowardZero RoundingMode = iota
+	// RoundHalfAwayFromZero rounds to the nearest integer, halves away from zero.
+	RoundHalfAwayFromZero
+	// RoundHalfEven rounds to the nearest integer, halves to the even one.
+	RoundHalfEven
+	// RoundDown rounds toward negative infinity.
+	RoundDown
+	// RoundUp rounds toward positive infinity.
+	RoundUp
+)
+
+/*
+Converts an integer to another integer type, returning
+an ErrOverflow error if the value doesn't fit into To.
+*/
+func Int[To, From ints | uints](el From) (To, error) {
+	newEl := To(el)
+
+	if From(newEl) != el || (el < 0) != (newEl < 0) {
+		return 0, overflowError[To](el)
+	}
+
+	return newEl, nil
+}
+
+/*
+Converts an integer to another integer type, clamping
+values that don't fit into To to its minimum or maximum.
+*/
+func Saturate[To, From ints | uints](el From) To {
+	minEl, maxEl := intRange[To]()
+
+	if el < 0 {
+		if int64(el) < minEl {
+			return To(minEl)
+		}
+	} else if uint64(el) > maxEl {
+		return To(maxEl)
+	}
+
+	return To(el)
+}
+
+/*
+Converts an integer to another integer type, keeping the low-order
+bits of values that don't fit into To, like a Go conversion does.
+*/
+func Wrap[To, From ints | uints](el From) To {
+	return To(el)
+}
+
+/*
+Converts a float to an integer type, rounding it according to mode.
+NaN and infinite values produce ErrNaN and ErrInf errors, and values
+that don't fit into To after rounding produce an ErrOverflow error.
+*/
+func FloatToInt[To ints | uints, From floats](el From, mode RoundingMode) (To, error) {
+	value := float64(el)
+
+	if err := checkFinite(value); err != nil {
+		return 0, err
+	}
+
+	switch mode {
+	case RoundHalfAwayFromZero:
+		value = math.Round(value)
+	case RoundHalfEven:
+		value = math.RoundToEven(value)
+	case RoundDown:
+		value = math.Floor(value)
+	case RoundUp:
+		value = math.Ceil(value)
+	default:
+		value = math.Trunc(value)
+	}
+
+	// The upper limit is a power of two, so it is exact as a float64.
+	var lower, upper float64
+
+	if minEl, _ := intRange[To](); minEl < 0 {
+		upper = math.Ldexp(1, bitSize[To]()-1)
+		lower = -upper
+	} else {
+		upper = math.Ldexp(1, bitSize[To]())
+	}
+
+	if value < lower || value >= upper {
+		return 0, overflowError[To](el)
+	}
+
+	return To(value), nil
+}
+
+/*
+Converts a number to a float type. NaN and infinite values produce
+ErrNaN and ErrInf errors, and finite values too large for To produce
+an ErrOverflow error. Integers may lose precision.
+*/
+func Float[To floats, From ints | uints | floats](el From) (To, error) {
+	if err := checkFinite(float64(el)); err != nil {
+		return 0, err
+	}
+
+	newEl := To(el)
+
+	if math.IsInf(float64(newEl), 0) {
+		return 0, overflowError[To](el)
+	}
+
+	return newEl, nil
+}
+
+func checkFinite(el float64) error {
+	if math.IsNaN(el) {
+		return fmt.Errorf("convert: %w", ErrNaN)
+	}
+
+	if math.IsInf(el, 0) {
+		return fmt.Errorf("convert: %w", ErrInf)
+	}
+
+	return nil
+}
+
+func overflowError[To any](el any) error {
+	var to To
+
+	return fmt.Errorf("convert: %v does not fit into %T: %w", el, to, ErrOverflow)
+}
+
+func intRange[T ints | uints]() (int64, uint64) {
+	var zero T
+
+	bits := bitSize[T]()
+
+	if zero-1 > 0 {
+		return 0, math.MaxUint64 >> (64 - bits)
+	}
+
+	return -1 << (bits - 1), math.MaxInt64 >> (64 - bits)
+}
@@ -0,0 +1,171 @@
+package convert_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    int64
+		expected int8
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			input:    -128,
+			expected: -128,
+		},
+		{
+			name:    "test2",
+			input:   128,
+			isError: true,
+		},
+		{
+			name:    "test3",
+			input:   -129,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		actual, err := convert.Int[int8](test.input)
+
+		if test.isError {
+			require.ErrorIs(t, err, convert.ErrOverflow)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, actual)
+	}
+
+	_, err := convert.Int[uint8](-1)
+	require.ErrorIs(t, err, convert.ErrOverflow)
+
+	_, err = convert.Int[int8](uint8(200))
+	require.ErrorIs(t, err, convert.ErrOverflow)
+
+	actual, err := convert.Int[uint64](int8(100))
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), actual)
+}
+
+func TestSaturate(t *testing.T) {
+	require.Equal(t, int8(127), convert.Saturate[int8](1000))
+	require.Equal(t, int8(-128), convert.Saturate[int8](-1000))
+	require.Equal(t, int8(5), convert.Saturate[int8](5))
+	require.Equal(t, uint8(0), convert.Saturate[uint8](-5))
+	require.Equal(t, uint16(65535), convert.Saturate[uint16](uint64(math.MaxUint64)))
+	require.Equal(t, int64(math.MaxInt64), convert.Saturate[int64](uint64(math.MaxUint64)))
+}
+
+func TestWrap(t *testing.T) {
+	require.Equal(t, int8(-56), convert.Wrap[int8](200))
+	require.Equal(t, uint8(255), convert.Wrap[uint8](-1))
+}
+
+func TestFloatToInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    float64
+		mode     convert.RoundingMode
+		expected int8
+		err      error
+	}{
+		{
+			name:     "test1",
+			input:    2.5,
+			mode:     convert.RoundTowardZero,
+			expected: 2,
+		},
+		{
+			name:     "test2",
+			input:    2.5,
+			mode:     convert.RoundHalfAwayFromZero,
+			expected: 3,
+		},
+		{
+			name:     "test3",
+			input:    2.5,
+			mode:     convert.RoundHalfEven,
+			expected: 2,
+		},
+		{
+			name:     "test4",
+			input:    -2.5,
+			mode:     convert.RoundDown,
+			expected: -3,
+		},
+		{
+			name:     "test5",
+			input:    -2.5,
+			mode:     convert.RoundUp,
+			expected: -2,
+		},
+		{
+			name:     "test6",
+			input:    127.4,
+			mode:     convert.RoundHalfEven,
+			expected: 127,
+		},
+		{
+			name:  "test7",
+			input: 127.6,
+			mode:  convert.RoundHalfEven,
+			err:   convert.ErrOverflow,
+		},
+		{
+			name:  "test8",
+			input: math.NaN(),
+			mode:  convert.RoundTowardZero,
+			err:   convert.ErrNaN,
+		},
+		{
+			name:  "test9",
+			input: math.Inf(-1),
+			mode:  convert.RoundTowardZero,
+			err:   convert.ErrInf,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		actual, err := convert.FloatToInt[int8](test.input, test.mode)
+
+		if test.err != nil {
+			require.ErrorIs(t, err, test.err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, actual)
+	}
+
+	_, err := convert.FloatToInt[int64](9.3e18, convert.RoundTowardZero)
+	require.ErrorIs(t, err, convert.ErrOverflow)
+
+	_, err = convert.FloatToInt[uint8](-0.5, convert.RoundDown)
+	require.ErrorIs(t, err, convert.ErrOverflow)
+}
+
+func TestFloat(t *testing.T) {
+	actual, err := convert.Float[float32](1.5)
+	require.NoError(t, err)
+	require.Equal(t, float32(1.5), actual)
+
+	_, err = convert.Float[float32](1e300)
+	require.ErrorIs(t, err, convert.ErrOverflow)
+
+	_, err = convert.Float[float64](math.NaN())
+	require.ErrorIs(t, err, convert.ErrNaN)
+
+	converted, err := convert.Float[float64](int64(42))
+	require.NoError(t, err)
+	require.Equal(t, 42.0, converted)
+}
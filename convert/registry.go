@@ -0,0 +1,260 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var ErrNoConverter = errors.New("no converter found")
+
+// DefaultRegistry is the registry used by To. It holds the built-in converters.
+var DefaultRegistry = NewRegistry()
+
+type converterFunc func(el any) (any, error)
+
+/*
+Registry keeps converters between pairs of types, so that
+the converter for a slice can be found by its types alone.
+A Registry is safe for concurrent use.
+*/
+type Registry struct {
+	mu            sync.RWMutex
+	converters    map[reflect.Type]map[reflect.Type]converterFunc
+	chaining      bool
+	intermediates map[reflect.Type]bool
+}
+
+/*
+Returns a registry holding the built-in converters: IntToString,
+FormatFloat(-1, 'g'), BoolToString and ComplexToString for every type
+they accept, and the parsers back from strings. Floats are formatted
+with the fewest digits that parse back to the same value. Since rune
+is int32, runes are converted to strings as numbers.
+*/
+func NewRegistry() *Registry {
+	r := &Registry{
+		converters:    make(map[reflect.Type]map[reflect.Type]converterFunc),
+		intermediates: make(map[reflect.Type]bool),
+	}
+
+	registerInt[int](r)
+	registerInt[int8](r)
+	registerInt[int16](r)
+	registerInt[int32](r)
+	registerInt[int64](r)
+	registerUint[uint](r)
+	registerUint[uint8](r)
+	registerUint[uint16](r)
+	registerUint[uint32](r)
+	registerUint[uint64](r)
+	registerFloat[float32](r)
+	registerFloat[float64](r)
+	registerComplex[complex64](r)
+	registerComplex[complex128](r)
+	Register(r, BoolToString)
+	RegisterTry(r, ParseBool)
+
+	return r
+}
+
+/*
+Enables or disables chaining: when no converter between two types
+is registered, a chain of converters through intermediate types is
+looked for, the shortest chain being preferred. Chains never pass
+through string unless AllowIntermediate[string] is called, since
+every built-in type converts to and from it and such chains would
+turn 1 into true or round floats on the way.
+*/
+func (r *Registry) SetChaining(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.chaining = enabled
+}
+
+/*
+Lets chains pass through T. Every type but string
+may be passed through by default.
+*/
+func AllowIntermediate[T any](r *Registry) {
+	t := typeOf[T]()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.intermediates[t] = true
+}
+
+/*
+Registers the converter from T to V,
+replacing the previous one for this pair of types.
+*/
+func Register[T, V any](r *Registry, convertFunc func(el T) V) {
+	RegisterTry(r, func(el T) (V, error) { return convertFunc(el), nil })
+}
+
+/*
+Registers a converter from T to V that can fail,
+replacing the previous one for this pair of types.
+*/
+func RegisterTry[T, V any](r *Registry, convertFunc func(el T) (V, error)) {
+	from, to := typeOf[T](), typeOf[V]()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.converters[from] == nil {
+		r.converters[from] = make(map[reflect.Type]converterFunc)
+	}
+
+	r.converters[from][to] = func(el any) (any, error) {
+		typedEl, _ := el.(T)
+		return convertFunc(typedEl)
+	}
+}
+
+/*
+Returns the converter from T to V found in the registry. An error
+wrapping ErrNoConverter is returned when there is none.
+*/
+func Lookup[T, V any](r *Registry) (func(el T) (V, error), error) {
+	from, to := typeOf[T](), typeOf[V]()
+
+	if from == to {
+		return func(el T) (V, error) {
+			newEl, _ := any(el).(V)
+			return newEl, nil
+		}, nil
+	}
+
+	path := r.path(from, to)
+	if path == nil {
+		return nil, fmt.Errorf("convert: %s to %s: %w", from, to, ErrNoConverter)
+	}
+
+	return func(el T) (V, error) {
+		var newEl any = el
+		var err error
+
+		for _, convertFunc := range path {
+			if newEl, err = convertFunc(newEl); err != nil {
+				var zero V
+				return zero, err
+			}
+		}
+
+		typedEl, _ := newEl.(V)
+		return typedEl, nil
+	}, nil
+}
+
+/*
+Converts a slice of type T to a slice of type V with the converter
+found in DefaultRegistry. Conversion failures are reported as in TryNew.
+*/
+func To[V, T any](slice []T) ([]V, error) {
+	return ToWith[V](DefaultRegistry, slice)
+}
+
+/*
+Converts a slice of type T to a slice of type V with the converter
+found in the given registry. Conversion failures are reported as in TryNew.
+*/
+func ToWith[V, T any](r *Registry, slice []T) ([]V, error) {
+	convertFunc, err := Lookup[T, V](r)
+	if err != nil {
+		return nil, err
+	}
+
+	return TryNew(slice, convertFunc)
+}
+
+func (r *Registry) path(from, to reflect.Type) []converterFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if convertFunc, isExists := r.converters[from][to]; isExists {
+		return []converterFunc{convertFunc}
+	}
+
+	if !r.chaining {
+		return nil
+	}
+
+	type step struct {
+		previous reflect.Type
+		convert  converterFunc
+	}
+
+	visited := map[reflect.Type]step{from: {}}
+	queue := []reflect.Type{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		nexts := make([]reflect.Type, 0, len(r.converters[current]))
+
+		for next := range r.converters[current] {
+			nexts = append(nexts, next)
+		}
+
+		// Sorting makes the choice between chains of equal length stable.
+		sort.Slice(nexts, func(i, j int) bool { return nexts[i].String() < nexts[j].String() })
+
+		for _, next := range nexts {
+			if _, isExists := visited[next]; isExists {
+				continue
+			}
+
+			if next != to && next == stringType && !r.intermediates[next] {
+				continue
+			}
+
+			visited[next] = step{previous: current, convert: r.converters[current][next]}
+
+			if next == to {
+				var path []converterFunc
+
+				for t := to; t != from; t = visited[t].previous {
+					path = append([]converterFunc{visited[t].convert}, path...)
+				}
+
+				return path
+			}
+
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+func registerInt[T ints](r *Registry) {
+	Register(r, IntToString[T])
+	RegisterTry(r, ParseInt[T])
+}
+
+func registerUint[T uints](r *Registry) {
+	Register(r, IntToString[T])
+	RegisterTry(r, ParseUint[T])
+}
+
+func registerFloat[T floats](r *Registry) {
+	Register(r, FormatFloat[T](-1, 'g'))
+	RegisterTry(r, ParseFloat[T])
+}
+
+func registerComplex[T complex](r *Registry) {
+	Register(r, ComplexToString[T])
+	RegisterTry(r, ParseComplex[T])
+}
+
+var stringType = typeOf[string]()
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,84 @@
+package convert_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTo(t *testing.T) {
+	strSlice, err := convert.To[string]([]int{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, strSlice)
+
+	strSlice, err = convert.To[string]([]float64{0.1, 1e-9, 5})
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.1", "1e-09", "5"}, strSlice)
+
+	boolSlice, err := convert.To[bool]([]string{"true", "x"})
+	require.Equal(t, []bool{true, false}, boolSlice)
+
+	var indexErr *convert.IndexError
+	require.True(t, errors.As(err, &indexErr))
+	require.Equal(t, 1, indexErr.Index)
+
+	_, err = convert.To[time.Duration]([]bool{true})
+	require.ErrorIs(t, err, convert.ErrNoConverter)
+}
+
+func TestRegistry(t *testing.T) {
+	type celsius float64
+	type fahrenheit float64
+
+	r := convert.NewRegistry()
+	convert.Register(r, func(el celsius) fahrenheit { return fahrenheit(el*9/5 + 32) })
+	convert.Register(r, func(el fahrenheit) string { return strconv.FormatFloat(float64(el), 'f', 1, 64) + "F" })
+
+	slice, err := convert.ToWith[fahrenheit](r, []celsius{0, 100})
+	require.NoError(t, err)
+	require.Equal(t, []fahrenheit{32, 212}, slice)
+
+	_, err = convert.ToWith[string](r, []celsius{0, 100})
+	require.ErrorIs(t, err, convert.ErrNoConverter)
+
+	r.SetChaining(true)
+
+	strSlice, err := convert.ToWith[string](r, []celsius{0, 100})
+	require.NoError(t, err)
+	require.Equal(t, []string{"32.0F", "212.0F"}, strSlice)
+
+	_, err = convert.ToWith[bool](r, []int{1})
+	require.ErrorIs(t, err, convert.ErrNoConverter)
+
+	_, err = convert.ToWith[float32](r, []float64{1e-9})
+	require.ErrorIs(t, err, convert.ErrNoConverter)
+
+	convert.AllowIntermediate[string](r)
+
+	floatSlice, err := convert.ToWith[float32](r, []float64{1e-9})
+	require.NoError(t, err)
+	require.Equal(t, []float32{1e-9}, floatSlice)
+
+	same, err := convert.ToWith[int](r, []int{1})
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, same)
+}
+
+func TestLookup(t *testing.T) {
+	r := convert.NewRegistry()
+	convert.RegisterTry(r, func(el string) (time.Duration, error) { return time.ParseDuration(el) })
+
+	parse, err := convert.Lookup[string, time.Duration](r)
+	require.NoError(t, err)
+
+	d, err := parse("1m30s")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, d)
+
+	_, err = parse("x")
+	require.Error(t, err)
+}
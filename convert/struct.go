@@ -0,0 +1,378 @@
+package convert
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+/*
+StructMapper copies the fields of a struct of type From into a new
+struct of type To. Fields are matched by name, or by the name given
+in a `convert:"name"` tag on either side; fields tagged `convert:"-"`
+are skipped. Nested structs are mapped recursively, pointers are
+adapted to values and back, and numbers are only widened, never
+narrowed. The mapping plan is built with reflection
+once, on the first call to Map, so a mapper must be configured before
+it is used. A StructMapper is safe for concurrent use.
+*/
+type StructMapper[From, To any] struct {
+	strict     bool
+	converters map[string]fieldConverter
+
+	once sync.Once
+	plan *structPlan
+	err  error
+}
+
+type fieldConverter struct {
+	from    reflect.Type
+	to      reflect.Type
+	convert func(el any) (any, error)
+}
+
+type structPlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	name   string
+	from   []int
+	to     int
+	assign func(dst, src reflect.Value) error
+}
+
+type planKey struct {
+	from, to reflect.Type
+	strict   bool
+}
+
+var (
+	plansMu sync.Mutex
+	plans   = make(map[planKey]*structPlan)
+)
+
+func NewStructMapper[From, To any]() *StructMapper[From, To] {
+	return &StructMapper[From, To]{converters: make(map[string]fieldConverter)}
+}
+
+/*
+Enables or disables strict mode, in which building the plan fails
+if a field of To, at any depth, has no field of From to be mapped from.
+*/
+func (m *StructMapper[From, To]) Strict(enabled bool) *StructMapper[From, To] {
+	m.strict = enabled
+
+	return m
+}
+
+/*
+Sets a custom converter for the field of To named field (or tagged
+with that name). The converter receives the matching field of From,
+or the whole From struct when F is From itself.
+*/
+func WithFieldConverter[From, To, F, V any](m *StructMapper[From, To], field string, convertFunc func(el F) (V, error)) *StructMapper[From, To] {
+	m.converters[field] = fieldConverter{
+		from: typeOf[F](),
+		to:   typeOf[V](),
+		convert: func(el any) (any, error) {
+			typedEl, _ := el.(F)
+			return convertFunc(typedEl)
+		},
+	}
+
+	return m
+}
+
+/*
+Returns a struct of type To filled with the fields of el.
+The signature fits TryNew, so slices of structs can be mapped at once.
+*/
+func (m *StructMapper[From, To]) Map(el From) (To, error) {
+	var newEl To
+
+	m.once.Do(m.build)
+
+	if m.err != nil {
+		return newEl, m.err
+	}
+
+	if err := m.plan.apply(reflect.ValueOf(&newEl).Elem(), reflect.ValueOf(&el).Elem()); err != nil {
+		return newEl, err
+	}
+
+	return newEl, nil
+}
+
+func (m *StructMapper[From, To]) build() {
+	from, to := typeOf[From](), typeOf[To]()
+
+	if from.Kind() != reflect.Struct || to.Kind() != reflect.Struct {
+		m.err = fmt.Errorf("convert: cannot map %s to %s: both must be structs", from, to)
+		return
+	}
+
+	plansMu.Lock()
+	defer plansMu.Unlock()
+
+	if len(m.converters) == 0 {
+		m.plan, m.err = compilePlan(from, to, m.strict)
+		return
+	}
+
+	// Custom converters only apply to this mapper, so its plan is not cached.
+	m.plan = &structPlan{}
+	m.err = m.plan.compile(from, to, m.strict, m.converters)
+}
+
+func compilePlan(from, to reflect.Type, strict bool) (*structPlan, error) {
+	key := planKey{from, to, strict}
+
+	if plan, isExists := plans[key]; isExists {
+		return plan, nil
+	}
+
+	// The plan is cached before compiling, so that recursive types refer to it.
+	plan := &structPlan{}
+	plans[key] = plan
+
+	if err := plan.compile(from, to, strict, nil); err != nil {
+		delete(plans, key)
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (p *structPlan) compile(from, to reflect.Type, strict bool, converters map[string]fieldConverter) error {
+	sources := make(map[string]reflect.StructField)
+
+	for _, field := range reflect.VisibleFields(from) {
+		if name, ok := mappedName(field); ok {
+			sources[name] = field
+		}
+	}
+
+	for i := 0; i < to.NumField(); i++ {
+		field := to.Field(i)
+
+		name, ok := mappedName(field)
+		if !ok {
+			continue
+		}
+
+		source, hasSource := sources[name]
+
+		if converter, isExists := converters[name]; isExists {
+			assign, err := customAssign(converter, from, source, hasSource, field)
+			if err != nil {
+				return err
+			}
+
+			p.fields = append(p.fields, fieldPlan{name: name, to: i, assign: assign})
+			continue
+		}
+
+		if !hasSource {
+			if strict {
+				return fmt.Errorf("convert: field %s.%s has no source in %s", to, field.Name, from)
+			}
+
+			continue
+		}
+
+		assign, err := assignFunc(source.Type, field.Type, strict)
+		if err != nil {
+			return fmt.Errorf("convert: field %s.%s: %w", to, field.Name, err)
+		}
+
+		p.fields = append(p.fields, fieldPlan{name: name, from: source.Index, to: i, assign: assign})
+	}
+
+	return nil
+}
+
+func (p *structPlan) apply(dst, src reflect.Value) error {
+	for _, field := range p.fields {
+		source := src
+
+		if field.from != nil {
+			var err error
+
+			if source, err = src.FieldByIndexErr(field.from); err != nil {
+				// A nil embedded pointer leaves the field zero.
+				continue
+			}
+		}
+
+		if err := field.assign(dst.Field(field.to), source); err != nil {
+			return fmt.Errorf("convert: field %s: %w", field.name, err)
+		}
+	}
+
+	return nil
+}
+
+func customAssign(converter fieldConverter, from reflect.Type, source reflect.StructField, hasSource bool, field reflect.StructField) (func(dst, src reflect.Value) error, error) {
+	if !converter.to.AssignableTo(field.Type) {
+		return nil, fmt.Errorf("convert: converter for field %s returns %s, not %s", field.Name, converter.to, field.Type)
+	}
+
+	if converter.from == from {
+		return func(dst, src reflect.Value) error {
+			newEl, err := converter.convert(src.Interface())
+			if err != nil {
+				return err
+			}
+
+			setAny(dst, newEl)
+
+			return nil
+		}, nil
+	}
+
+	if !hasSource {
+		return nil, fmt.Errorf("convert: converter for field %s has no source field in %s", field.Name, from)
+	}
+
+	if !source.Type.AssignableTo(converter.from) {
+		return nil, fmt.Errorf("convert: converter for field %s accepts %s, not %s", field.Name, converter.from, source.Type)
+	}
+
+	index := source.Index
+
+	return func(dst, src reflect.Value) error {
+		sourceValue, err := src.FieldByIndexErr(index)
+		if err != nil {
+			return nil
+		}
+
+		newEl, err := converter.convert(sourceValue.Interface())
+		if err != nil {
+			return err
+		}
+
+		setAny(dst, newEl)
+
+		return nil
+	}, nil
+}
+
+func assignFunc(from, to reflect.Type, strict bool) (func(dst, src reflect.Value) error, error) {
+	switch {
+	case from.AssignableTo(to):
+		return func(dst, src reflect.Value) error {
+			dst.Set(src)
+			return nil
+		}, nil
+	case from.Kind() == reflect.Pointer && to.Kind() == reflect.Pointer:
+		inner, err := assignFunc(from.Elem(), to.Elem(), strict)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(dst, src reflect.Value) error {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+
+			newEl := reflect.New(to.Elem())
+			if err := inner(newEl.Elem(), src.Elem()); err != nil {
+				return err
+			}
+
+			dst.Set(newEl)
+
+			return nil
+		}, nil
+	case from.Kind() == reflect.Pointer:
+		inner, err := assignFunc(from.Elem(), to, strict)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(dst, src reflect.Value) error {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+
+			return inner(dst, src.Elem())
+		}, nil
+	case to.Kind() == reflect.Pointer:
+		inner, err := assignFunc(from, to.Elem(), strict)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(dst, src reflect.Value) error {
+			newEl := reflect.New(to.Elem())
+			if err := inner(newEl.Elem(), src); err != nil {
+				return err
+			}
+
+			dst.Set(newEl)
+
+			return nil
+		}, nil
+	case from.Kind() == reflect.Struct && to.Kind() == reflect.Struct:
+		plan, err := compilePlan(from, to, strict)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(dst, src reflect.Value) error {
+			return plan.apply(dst, src)
+		}, nil
+	case from.Kind() == to.Kind() && from.ConvertibleTo(to) || isWidening(from, to):
+		return func(dst, src reflect.Value) error {
+			dst.Set(src.Convert(to))
+			return nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cannot map %s to %s", from, to)
+}
+
+// Reports whether every value of a numeric type from fits into the numeric type to.
+func isWidening(from, to reflect.Type) bool {
+	family := func(t reflect.Type) int {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return 1
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return 2
+		case reflect.Float32, reflect.Float64:
+			return 3
+		}
+
+		return 0
+	}
+
+	return family(from) != 0 && family(from) == family(to) && from.Bits() <= to.Bits()
+}
+
+func setAny(dst reflect.Value, el any) {
+	if el == nil {
+		dst.SetZero()
+		return
+	}
+
+	dst.Set(reflect.ValueOf(el))
+}
+
+func mappedName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	switch tag := field.Tag.Get("convert"); tag {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	default:
+		return tag, true
+	}
+}
@@ -0,0 +1,126 @@
+package convert_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+type addressDTO struct {
+	City   string
+	Street string
+}
+
+type userDTO struct {
+	ID       string `convert:"Id"`
+	FullName string `convert:"Name"`
+	Age      int32
+	Address  *addressDTO
+	Tags     string
+	Internal string `convert:"-"`
+}
+
+type address struct {
+	City string
+}
+
+type user struct {
+	Id      string
+	Name    string
+	Age     int64
+	Address address
+	Tags    []string
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+type nodeDTO struct {
+	Value int
+	Next  *nodeDTO
+}
+
+func TestStructMapper(t *testing.T) {
+	mapper := convert.NewStructMapper[userDTO, user]()
+	mapper = convert.WithFieldConverter(mapper, "Tags", func(el string) ([]string, error) {
+		return strings.Split(el, ","), nil
+	})
+
+	actual, err := mapper.Map(userDTO{
+		ID:       "u1",
+		FullName: "Kate",
+		Age:      25,
+		Address:  &addressDTO{City: "Paris", Street: "Rivoli"},
+		Tags:     "a,b",
+		Internal: "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, user{"u1", "Kate", 25, address{"Paris"}, []string{"a", "b"}}, actual)
+
+	actual, err = mapper.Map(userDTO{ID: "u2"})
+	require.NoError(t, err)
+	require.Equal(t, user{Id: "u2", Tags: []string{""}}, actual)
+}
+
+func TestStructMapperWholeSource(t *testing.T) {
+	type summary struct {
+		Label string
+	}
+
+	mapper := convert.WithFieldConverter(convert.NewStructMapper[userDTO, summary](), "Label",
+		func(el userDTO) (string, error) {
+			if el.Age < 0 {
+				return "", errors.New("negative age")
+			}
+
+			return el.FullName + " (" + strconv.Itoa(int(el.Age)) + ")", nil
+		})
+
+	actual, err := mapper.Map(userDTO{FullName: "Kate", Age: 25})
+	require.NoError(t, err)
+	require.Equal(t, summary{"Kate (25)"}, actual)
+
+	_, err = mapper.Map(userDTO{Age: -1})
+	require.Error(t, err)
+
+	slice, err := convert.TryNew([]userDTO{{FullName: "John", Age: 17}}, mapper.Map)
+	require.NoError(t, err)
+	require.Equal(t, []summary{{"John (17)"}}, slice)
+}
+
+func TestStructMapperStrict(t *testing.T) {
+	type target struct {
+		Name    string
+		Missing int
+	}
+
+	_, err := convert.NewStructMapper[userDTO, target]().Map(userDTO{})
+	require.NoError(t, err)
+
+	_, err = convert.NewStructMapper[userDTO, target]().Strict(true).Map(userDTO{})
+	require.ErrorContains(t, err, "Missing")
+
+	_, err = convert.NewStructMapper[userDTO, int]().Map(userDTO{})
+	require.Error(t, err)
+
+	type incompatible struct {
+		Age bool
+	}
+
+	_, err = convert.NewStructMapper[userDTO, incompatible]().Map(userDTO{})
+	require.ErrorContains(t, err, "cannot map")
+}
+
+func TestStructMapperRecursive(t *testing.T) {
+	mapper := convert.NewStructMapper[node, nodeDTO]()
+
+	actual, err := mapper.Map(node{1, &node{2, &node{3, nil}}})
+	require.NoError(t, err)
+	require.Equal(t, nodeDTO{1, &nodeDTO{2, &nodeDTO{3, nil}}}, actual)
+}
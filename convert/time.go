@@ -0,0 +1,246 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var iso8601Duration = regexp.MustCompile(`^([-+])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+func TimeToString(layout string) func(el time.Time) string {
+	return func(el time.Time) string {
+		return el.Format(layout)
+	}
+}
+
+/*
+Returns a parser of times written with the given layout. Times
+without a time zone are taken to be in loc, or in UTC if loc is nil.
+*/
+func ParseTime(layout string, loc *time.Location) func(el string) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return func(el string) (time.Time, error) {
+		return time.ParseInLocation(layout, el, loc)
+	}
+}
+
+func TimeToUnix(el time.Time) int64 {
+	return el.Unix()
+}
+
+func TimeToUnixMilli(el time.Time) int64 {
+	return el.UnixMilli()
+}
+
+func TimeToUnixNano(el time.Time) int64 {
+	return el.UnixNano()
+}
+
+// Returns a converter of Unix seconds to times in loc, or in UTC if loc is nil.
+func UnixToTime[T ints](loc *time.Location) func(el T) time.Time {
+	return unixToTime(loc, func(el T) time.Time { return time.Unix(int64(el), 0) })
+}
+
+// Returns a converter of Unix milliseconds to times in loc, or in UTC if loc is nil.
+func UnixMilliToTime[T ints](loc *time.Location) func(el T) time.Time {
+	return unixToTime(loc, func(el T) time.Time { return time.UnixMilli(int64(el)) })
+}
+
+// Returns a converter of Unix nanoseconds to times in loc, or in UTC if loc is nil.
+func UnixNanoToTime[T ints](loc *time.Location) func(el T) time.Time {
+	return unixToTime(loc, func(el T) time.Time { return time.Unix(0, int64(el)) })
+}
+
+/*
+Writes a duration in the ISO 8601 format, such as "PT1H30M" or
+"P2DT0.5S". Days are taken to be 24 hours long.
+*/
+func DurationToISO8601(el time.Duration) string {
+	if el == 0 {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+
+	// A uint64 holds the absolute value of the smallest duration too.
+	abs := uint64(el)
+	if el < 0 {
+		sb.WriteByte('-')
+		abs = -abs
+	}
+
+	sb.WriteByte('P')
+
+	day := uint64(24 * time.Hour)
+	if days := abs / day; days > 0 {
+		sb.WriteString(strconv.FormatUint(days, 10) + "D")
+		abs %= day
+	}
+
+	if abs == 0 {
+		return sb.String()
+	}
+
+	sb.WriteByte('T')
+
+	if hours := abs / uint64(time.Hour); hours > 0 {
+		sb.WriteString(strconv.FormatUint(hours, 10) + "H")
+		abs %= uint64(time.Hour)
+	}
+
+	if minutes := abs / uint64(time.Minute); minutes > 0 {
+		sb.WriteString(strconv.FormatUint(minutes, 10) + "M")
+		abs %= uint64(time.Minute)
+	}
+
+	if abs > 0 {
+		seconds := strconv.FormatUint(abs/uint64(time.Second), 10)
+
+		if nanos := abs % uint64(time.Second); nanos > 0 {
+			seconds += strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0")
+		}
+
+		sb.WriteString(seconds + "S")
+	}
+
+	return sb.String()
+}
+
+/*
+Parses an ISO 8601 duration made of weeks, days, hours, minutes and
+seconds, such as "PT1H30M" or "-P1W2DT0,5S". Years and months have
+no fixed length and are rejected; days are taken to be 24 hours long.
+*/
+func ParseISO8601Duration(el string) (time.Duration, error) {
+	match := iso8601Duration.FindStringSubmatch(el)
+	if match == nil || strings.HasSuffix(el, "T") || strings.HasSuffix(el, "P") {
+		return 0, fmt.Errorf("convert: invalid ISO 8601 duration %q", el)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total float64
+	var duration time.Duration
+
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+
+		part, err := scaleDecimal(strings.Replace(match[i+2], ",", ".", 1), unit)
+		if err != nil {
+			return 0, fmt.Errorf("convert: ISO 8601 duration %q: %w", el, err)
+		}
+
+		total += float64(part)
+		duration += part
+	}
+
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("convert: ISO 8601 duration %q: %w", el, ErrOverflow)
+	}
+
+	if match[1] == "-" {
+		duration = -duration
+	}
+
+	return duration, nil
+}
+
+/*
+Writes a duration the way people read it, with its two largest units,
+such as "2d 3h", "1h 30m", "45s" or "350ms". Durations below a second
+are written in milliseconds, microseconds or nanoseconds.
+*/
+func DurationToHuman(el time.Duration) string {
+	if el == 0 {
+		return "0s"
+	}
+
+	sign := ""
+	abs := uint64(el)
+
+	if el < 0 {
+		sign, abs = "-", -abs
+	}
+
+	if abs < uint64(time.Second) {
+		switch {
+		case abs >= uint64(time.Millisecond):
+			return sign + strconv.FormatUint(abs/uint64(time.Millisecond), 10) + "ms"
+		case abs >= uint64(time.Microsecond):
+			return sign + strconv.FormatUint(abs/uint64(time.Microsecond), 10) + "µs"
+		default:
+			return sign + strconv.FormatUint(abs, 10) + "ns"
+		}
+	}
+
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	parts := make([]string, 0, 2)
+
+	for _, unit := range units {
+		if count := abs / uint64(unit.size); count > 0 {
+			parts = append(parts, strconv.FormatUint(count, 10)+unit.suffix)
+			abs %= uint64(unit.size)
+		} else if len(parts) > 0 {
+			break
+		}
+
+		if len(parts) == 2 {
+			break
+		}
+	}
+
+	return sign + strings.Join(parts, " ")
+}
+
+func unixToTime[T ints](loc *time.Location, toTime func(el T) time.Time) func(el T) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return func(el T) time.Time {
+		return toTime(el).In(loc)
+	}
+}
+
+// Multiplies a non-negative decimal number by unit without going through floats.
+func scaleDecimal(decimal string, unit time.Duration) (time.Duration, error) {
+	intPart, fracPart, _ := strings.Cut(decimal, ".")
+
+	whole, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || whole > math.MaxInt64/int64(unit) {
+		return 0, ErrOverflow
+	}
+
+	duration := time.Duration(whole) * unit
+	scale := int64(unit)
+
+	for _, digit := range fracPart {
+		if scale /= 10; scale == 0 {
+			break
+		}
+
+		duration += time.Duration(int64(digit-'0') * scale)
+	}
+
+	if duration < 0 {
+		return 0, ErrOverflow
+	}
+
+	return duration, nil
+}
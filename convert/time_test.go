@@ -0,0 +1,166 @@
+package convert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeToString(t *testing.T) {
+	times := []time.Time{time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)}
+
+	require.Equal(t, []string{"2023-05-01"}, convert.New(times, convert.TimeToString(time.DateOnly)))
+	require.Equal(t, []string{"2023-05-01T12:30:00Z"}, convert.New(times, convert.TimeToString(time.RFC3339)))
+}
+
+func TestParseTime(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name     string
+		layout   string
+		loc      *time.Location
+		input    string
+		expected time.Time
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			layout:   time.DateTime,
+			loc:      moscow,
+			input:    "2023-05-01 12:30:00",
+			expected: time.Date(2023, 5, 1, 12, 30, 0, 0, moscow),
+		},
+		{
+			name:     "test2",
+			layout:   time.DateTime,
+			loc:      nil,
+			input:    "2023-05-01 12:30:00",
+			expected: time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "test3",
+			layout:   time.RFC3339,
+			loc:      moscow,
+			input:    "2023-05-01T12:30:00Z",
+			expected: time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "test4",
+			layout:  time.DateOnly,
+			input:   "01.05.2023",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		actual, err := convert.ParseTime(test.layout, test.loc)(test.input)
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.True(t, test.expected.Equal(actual))
+		require.Equal(t, test.expected.Location().String(), actual.Location().String())
+	}
+}
+
+func TestUnix(t *testing.T) {
+	moment := time.Date(2023, 5, 1, 12, 30, 0, 123456789, time.UTC)
+
+	require.Equal(t, int64(1682944200), convert.TimeToUnix(moment))
+	require.Equal(t, int64(1682944200123), convert.TimeToUnixMilli(moment))
+	require.Equal(t, int64(1682944200123456789), convert.TimeToUnixNano(moment))
+
+	require.Equal(t, moment.Truncate(time.Second), convert.UnixToTime[int64](nil)(1682944200))
+	require.Equal(t, moment.Truncate(time.Millisecond), convert.UnixMilliToTime[int64](nil)(1682944200123))
+	require.Equal(t, moment, convert.UnixNanoToTime[int64](nil)(1682944200123456789))
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	require.Equal(t, 15, convert.UnixToTime[int32](moscow)(1682944200).Hour())
+}
+
+func TestDurationToISO8601(t *testing.T) {
+	tests := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{0, "PT0S"},
+		{90 * time.Minute, "PT1H30M"},
+		{50*time.Hour + 500*time.Millisecond, "P2DT2H0.5S"},
+		{48 * time.Hour, "P2D"},
+		{-1500 * time.Millisecond, "-PT1.5S"},
+		{time.Nanosecond, "PT0.000000001S"},
+	}
+
+	for _, test := range tests {
+		t.Log(test.expected)
+		require.Equal(t, test.expected, convert.DurationToISO8601(test.input))
+	}
+}
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		isError  bool
+	}{
+		{input: "PT0S", expected: 0},
+		{input: "PT1H30M", expected: 90 * time.Minute},
+		{input: "P1W2DT0,5S", expected: 9*24*time.Hour + 500*time.Millisecond},
+		{input: "-PT1.5S", expected: -1500 * time.Millisecond},
+		{input: "PT0.5H", expected: 30 * time.Minute},
+		{input: "P1Y", isError: true},
+		{input: "P1M", isError: true},
+		{input: "P", isError: true},
+		{input: "PT", isError: true},
+		{input: "1H", isError: true},
+		{input: "P999999999999D", isError: true},
+	}
+
+	for _, test := range tests {
+		t.Log(test.input)
+		actual, err := convert.ParseISO8601Duration(test.input)
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, actual)
+	}
+
+	for _, d := range []time.Duration{0, 90 * time.Minute, 50*time.Hour + 500*time.Millisecond, -time.Nanosecond} {
+		actual, err := convert.ParseISO8601Duration(convert.DurationToISO8601(d))
+		require.NoError(t, err)
+		require.Equal(t, d, actual)
+	}
+}
+
+func TestDurationToHuman(t *testing.T) {
+	tests := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{350 * time.Millisecond, "350ms"},
+		{42 * time.Microsecond, "42µs"},
+		{7, "7ns"},
+		{45 * time.Second, "45s"},
+		{90 * time.Minute, "1h 30m"},
+		{51*time.Hour + 20*time.Minute, "2d 3h"},
+		{time.Hour + 5*time.Second, "1h"},
+		{-65 * time.Second, "-1m 5s"},
+	}
+
+	for _, test := range tests {
+		t.Log(test.expected)
+		require.Equal(t, test.expected, convert.DurationToHuman(test.input))
+	}
+}
@@ -0,0 +1,148 @@
+package gofunc
+
+import "sort"
+
+// Pair is a couple of values, returned by operations that rank or zip elements.
+type Pair[F, S any] struct {
+	First  F
+	Second S
+}
+
+// ConflictPolicy decides which element wins when several elements share the same key.
+type ConflictPolicy int
+
+const (
+	// KeepLast keeps the last element met for a key.
+	KeepLast ConflictPolicy = iota
+	// KeepFirst keeps the first element met for a key.
+	KeepFirst
+)
+
+/*
+Returns the count of elements in collection
+that match the provided condition.
+*/
+func (c *collection[T]) Count(predicate func(el T) bool) int {
+	var count int
+
+	if predicate == nil {
+		return count
+	}
+
+	for _, value := range c.data {
+		if predicate(value) {
+			count++
+		}
+	}
+
+	return count
+}
+
+/*
+Returns the number of occurrences of each element in collection.
+*/
+func (c *collection[T]) Frequencies() map[T]int {
+	frequencies := make(map[T]int)
+
+	for _, value := range c.data {
+		frequencies[value]++
+	}
+
+	return frequencies
+}
+
+/*
+Returns the n most common elements of this collection paired with
+the number of their occurrences, from the most common to the least.
+Elements with equal counts keep the order of their first occurrence.
+If n < 0, all the elements are returned.
+*/
+func (c *collection[T]) MostCommon(n int) []Pair[T, int] {
+	frequencies := make(map[T]int)
+	ranked := make([]Pair[T, int], 0)
+
+	for _, value := range c.data {
+		if _, isExists := frequencies[value]; !isExists {
+			ranked = append(ranked, Pair[T, int]{First: value})
+		}
+
+		frequencies[value]++
+	}
+
+	for i := range ranked {
+		ranked[i].Second = frequencies[ranked[i].First]
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Second > ranked[j].Second
+	})
+
+	if n >= 0 && n < len(ranked) {
+		ranked = ranked[:n]
+	}
+
+	return ranked
+}
+
+/*
+Returns the number of elements in collection for each key
+produced by the key function.
+*/
+func CountBy[T, K comparable](c *collection[T], key func(el T) K) map[K]int {
+	counts := make(map[K]int)
+
+	if key == nil {
+		return counts
+	}
+
+	for _, value := range c.data {
+		counts[key(value)]++
+	}
+
+	return counts
+}
+
+/*
+Returns a map containing the key-value pairs produced by
+the transform function applied to the elements of collection.
+When several elements produce the same key, the last one wins.
+*/
+func Associate[T, K comparable, V any](c *collection[T], transform func(el T) (K, V)) map[K]V {
+	associated := make(map[K]V, len(c.data))
+
+	if transform == nil {
+		return associated
+	}
+
+	for _, value := range c.data {
+		k, v := transform(value)
+		associated[k] = v
+	}
+
+	return associated
+}
+
+/*
+Returns a map of the elements of collection indexed by the key
+function. The policy decides which element is kept when several
+elements share the same key.
+*/
+func IndexBy[T, K comparable](c *collection[T], key func(el T) K, policy ConflictPolicy) map[K]T {
+	indexed := make(map[K]T, len(c.data))
+
+	if key == nil {
+		return indexed
+	}
+
+	for _, value := range c.data {
+		k := key(value)
+
+		if _, isExists := indexed[k]; isExists && policy == KeepFirst {
+			continue
+		}
+
+		indexed[k] = value
+	}
+
+	return indexed
+}
@@ -0,0 +1,185 @@
+package gofunc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		script   func(int) bool
+		expected int
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			script:   func(el int) bool { return el%2 == 0 },
+			expected: 2,
+		},
+		{
+			name:     "test2",
+			input:    New([]int{}),
+			script:   func(el int) bool { return el%2 == 0 },
+			expected: 0,
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			script:   nil,
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.Count(test.script)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestFrequencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[string]
+		expected map[string]int
+	}{
+		{
+			name:     "test1",
+			input:    New([]string{"a", "b", "a", "c", "a"}),
+			expected: map[string]int{"a": 3, "b": 1, "c": 1},
+		},
+		{
+			name:     "test2",
+			input:    New([]string{}),
+			expected: map[string]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.Frequencies()
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[string]
+		n        int
+		expected []Pair[string, int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]string{"c", "a", "b", "a", "b", "a"}),
+			n:        2,
+			expected: []Pair[string, int]{{"a", 3}, {"b", 2}},
+		},
+		{
+			name:     "test2",
+			input:    New([]string{"c", "a", "b", "b", "a"}),
+			n:        -1,
+			expected: []Pair[string, int]{{"a", 2}, {"b", 2}, {"c", 1}},
+		},
+		{
+			name:     "test3",
+			input:    New([]string{}),
+			n:        3,
+			expected: []Pair[string, int]{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.MostCommon(test.n)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		key      func(int) bool
+		expected map[bool]int
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			key:      func(el int) bool { return el%2 == 0 },
+			expected: map[bool]int{true: 2, false: 3},
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			key:      nil,
+			expected: map[bool]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := CountBy(test.input, test.key)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *collection[int]
+		transform func(int) (string, int)
+		expected  map[string]int
+	}{
+		{
+			name:      "test1",
+			input:     New([]int{1, 2, 3}),
+			transform: func(el int) (string, int) { return strconv.Itoa(el), el * el },
+			expected:  map[string]int{"1": 1, "2": 4, "3": 9},
+		},
+		{
+			name:      "test2",
+			input:     New([]int{1, 2, 3}),
+			transform: nil,
+			expected:  map[string]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := Associate(test.input, test.transform)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestIndexBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[distinctUser]
+		policy   ConflictPolicy
+		expected map[int]distinctUser
+	}{
+		{
+			name:     "test1",
+			input:    New([]distinctUser{{1, 1}, {2, 1}, {1, 2}}),
+			policy:   KeepLast,
+			expected: map[int]distinctUser{1: {1, 2}, 2: {2, 1}},
+		},
+		{
+			name:     "test2",
+			input:    New([]distinctUser{{1, 1}, {2, 1}, {1, 2}}),
+			policy:   KeepFirst,
+			expected: map[int]distinctUser{1: {1, 1}, 2: {2, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := IndexBy(test.input, func(el distinctUser) int { return el.id }, test.policy)
+		require.Equal(t, test.expected, result)
+	}
+}
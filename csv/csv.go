@@ -0,0 +1,362 @@
+/*
+Package csv reads and writes CSV documents as slices of structs.
+It works on slices rather than collections, as convert does: rows
+need not be comparable, and gofunc.New and ToSlice bridge the two:
+the rows read are wrapped with gofunc.New, and a collection is
+written back with csv.Write(w, c.ToSlice()).
+*/
+package csv
+
+import (
+	"encoding"
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kdl-dev/gofunc/convert"
+)
+
+// RowError records a failure to read or write one row of a CSV document.
+type RowError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("csv: row %d: %v", e.Row, e.Err)
+	}
+
+	return fmt.Sprintf("csv: row %d, column %q: %v", e.Row, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type field struct {
+	column string
+	index  int
+}
+
+/*
+Reads all the records of r into a slice of structs, ready to be
+wrapped with gofunc.New. The first record is the header: its columns
+are matched against the `csv:"column"` tags of the struct fields, or
+against the field names when there is no tag. Fields tagged with
+`csv:"-"`, unexported fields and unknown columns are ignored.
+Pointer fields are left nil by empty cells. Numbers and booleans are
+parsed with the convert package after trimming spaces, while strings
+are kept as they are; set TrimLeadingSpace on r to trim them. The
+field types are checked before anything is read.
+Rows that fail to convert are skipped and reported as *RowError
+values joined into the returned error, next to the rows that were read.
+*/
+func Read[T any](r *stdcsv.Reader) ([]T, error) {
+	fields, err := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return make([]T, 0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make([]int, len(header))
+	byColumn := make(map[string]int, len(fields))
+
+	for _, f := range fields {
+		byColumn[f.column] = f.index
+	}
+
+	for i, name := range header {
+		columns[i] = -1
+
+		if index, isExists := byColumn[strings.TrimSpace(name)]; isExists {
+			columns[i] = index
+		}
+	}
+
+	rows := make([]T, 0)
+	var errs []error
+
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			var parseErr *stdcsv.ParseError
+
+			if errors.As(err, &parseErr) && !errors.Is(err, stdcsv.ErrFieldCount) {
+				return rows, err
+			}
+
+			errs = append(errs, &RowError{Row: row, Err: err})
+			continue
+		}
+
+		var value T
+		target := reflect.ValueOf(&value).Elem()
+		isValid := true
+
+		for i, cell := range record {
+			if i >= len(columns) || columns[i] < 0 {
+				continue
+			}
+
+			if err := parseCell(cell, target.Field(columns[i])); err != nil {
+				errs = append(errs, &RowError{Row: row, Column: header[i], Err: err})
+				isValid = false
+
+				break
+			}
+		}
+
+		if isValid {
+			rows = append(rows, value)
+		}
+	}
+
+	return rows, errors.Join(errs...)
+}
+
+/*
+Writes a header and one record per element of rows to w. The columns
+argument sets which columns are written and in what order; when it
+is empty, all the columns are written in the order of the struct fields.
+Nil pointer fields are written as empty cells. The field types are
+checked before anything is written.
+*/
+func Write[T any](w *stdcsv.Writer, rows []T, columns ...string) error {
+	fields, err := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	if len(columns) > 0 {
+		byColumn := make(map[string]field, len(fields))
+
+		for _, f := range fields {
+			byColumn[f.column] = f
+		}
+
+		ordered := make([]field, len(columns))
+
+		for i, column := range columns {
+			f, isExists := byColumn[column]
+			if !isExists {
+				return fmt.Errorf("csv: unknown column %q", column)
+			}
+
+			ordered[i] = f
+		}
+
+		fields = ordered
+	}
+
+	record := make([]string, len(fields))
+
+	for i, f := range fields {
+		record[i] = f.column
+	}
+
+	if err := w.Write(record); err != nil {
+		return err
+	}
+
+	for row, value := range rows {
+		source := reflect.ValueOf(value)
+
+		for i, f := range fields {
+			cell, err := formatCell(source.Field(f.index))
+			if err != nil {
+				return &RowError{Row: row + 2, Column: f.column, Err: err}
+			}
+
+			record[i] = cell
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+func structFields(t reflect.Type) ([]field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %s is not a struct", t)
+	}
+
+	fields := make([]field, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		column := sf.Tag.Get("csv")
+
+		if !sf.IsExported() || column == "-" {
+			continue
+		}
+
+		if column == "" {
+			column = sf.Name
+		}
+
+		if !isSupported(sf.Type) {
+			return nil, fmt.Errorf("csv: field %s has unsupported type %s", sf.Name, sf.Type)
+		}
+
+		fields = append(fields, field{column: column, index: i})
+	}
+
+	return fields, nil
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+/*
+Returns whether fields of type t can be read from and written to cells:
+strings, booleans, numbers, types implementing both encoding.TextMarshaler
+and encoding.TextUnmarshaler, and pointers to any of these.
+*/
+func isSupported(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+
+		if t.Kind() == reflect.Pointer {
+			return false
+		}
+	}
+
+	if t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// An empty cell sets a pointer field to nil.
+func parseCell(cell string, target reflect.Value) error {
+	if target.Kind() == reflect.Pointer {
+		if strings.TrimSpace(cell) == "" {
+			target.SetZero()
+			return nil
+		}
+
+		value := reflect.New(target.Type().Elem())
+		if err := parseCell(cell, value.Elem()); err != nil {
+			return err
+		}
+
+		target.Set(value)
+
+		return nil
+	}
+
+	if unmarshaler, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(cell))
+	}
+
+	// Strings are kept as they are; trimming them is left to
+	// the TrimLeadingSpace option of the reader.
+	if target.Kind() != reflect.String {
+		cell = strings.TrimSpace(cell)
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(cell)
+	case reflect.Bool:
+		parsed, err := convert.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+
+		target.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := convert.ParseInt[int64](cell)
+		if err != nil {
+			return err
+		} else if target.OverflowInt(parsed) {
+			return fmt.Errorf("%s does not fit into %s: %w", cell, target.Type(), convert.ErrOverflow)
+		}
+
+		target.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := convert.ParseUint[uint64](cell)
+		if err != nil {
+			return err
+		} else if target.OverflowUint(parsed) {
+			return fmt.Errorf("%s does not fit into %s: %w", cell, target.Type(), convert.ErrOverflow)
+		}
+
+		target.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := convert.ParseFloat[float64](cell)
+		if err != nil {
+			return err
+		} else if target.OverflowFloat(parsed) {
+			return fmt.Errorf("%s does not fit into %s: %w", cell, target.Type(), convert.ErrOverflow)
+		}
+
+		target.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported type %s", target.Type())
+	}
+
+	return nil
+}
+
+// A nil pointer field is written as an empty cell.
+func formatCell(source reflect.Value) (string, error) {
+	if source.Kind() == reflect.Pointer {
+		if source.IsNil() {
+			return "", nil
+		}
+
+		source = source.Elem()
+	}
+
+	if marshaler, ok := source.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch source.Kind() {
+	case reflect.String:
+		return source.String(), nil
+	case reflect.Bool:
+		return convert.BoolToString(source.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return convert.IntToString(source.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return convert.IntToString(source.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		// FloatToString always prints six decimals, which would lose precision.
+		return strconv.FormatFloat(source.Float(), 'g', -1, source.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", source.Type())
+}
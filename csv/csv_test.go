@@ -0,0 +1,185 @@
+package csv_test
+
+import (
+	"bytes"
+	stdcsv "encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kdl-dev/gofunc"
+	"github.com/kdl-dev/gofunc/convert"
+	"github.com/kdl-dev/gofunc/csv"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	Id       int64     `csv:"id"`
+	Name     string    `csv:"name"`
+	Age      uint8     `csv:"age"`
+	Score    float64   `csv:"score"`
+	Active   bool      `csv:"active"`
+	Birthday time.Time `csv:"birthday"`
+	Note     string    `csv:"-"`
+}
+
+func TestRead(t *testing.T) {
+	input := "name,id,age,score,active,birthday,extra\n" +
+		"Kate,1,25,9.5,true,1998-05-01T00:00:00Z,x\n" +
+		"John,2,300,7,false,2006-01-02T00:00:00Z,y\n" +
+		"Sam,3,22,8.25,true,2001-03-04T00:00:00Z,z\n"
+
+	rows, err := csv.Read[user](stdcsv.NewReader(strings.NewReader(input)))
+
+	var rowErr *csv.RowError
+	require.True(t, errors.As(err, &rowErr))
+	require.Equal(t, 3, rowErr.Row)
+	require.Equal(t, "age", rowErr.Column)
+
+	require.Equal(t, []user{
+		{1, "Kate", 25, 9.5, true, time.Date(1998, 5, 1, 0, 0, 0, 0, time.UTC), ""},
+		{3, "Sam", 22, 8.25, true, time.Date(2001, 3, 4, 0, 0, 0, 0, time.UTC), ""},
+	}, rows)
+
+	names := gofunc.New(rows).
+		Filter(func(el user) bool { return el.Age > 24 }).
+		ToString(func(el user) string { return el.Name })
+	require.Equal(t, "Kate", names)
+}
+
+func TestReadEmpty(t *testing.T) {
+	rows, err := csv.Read[user](stdcsv.NewReader(strings.NewReader("")))
+	require.NoError(t, err)
+	require.Equal(t, []user{}, rows)
+
+	_, err = csv.Read[int](stdcsv.NewReader(strings.NewReader("a\n1\n")))
+	require.Error(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	rows := []user{
+		{1, "Kate", 25, 9.5, true, time.Date(1998, 5, 1, 0, 0, 0, 0, time.UTC), "note"},
+		{2, "John", 17, 7, false, time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), ""},
+	}
+
+	tests := []struct {
+		name     string
+		columns  []string
+		expected string
+		isError  bool
+	}{
+		{
+			name:    "test1",
+			columns: nil,
+			expected: "id,name,age,score,active,birthday\n" +
+				"1,Kate,25,9.5,true,1998-05-01T00:00:00Z\n" +
+				"2,John,17,7,false,2006-01-02T00:00:00Z\n",
+		},
+		{
+			name:     "test2",
+			columns:  []string{"name", "id"},
+			expected: "name,id\nKate,1\nJohn,2\n",
+		},
+		{
+			name:    "test3",
+			columns: []string{"unknown"},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		var buf bytes.Buffer
+		err := csv.Write(stdcsv.NewWriter(&buf), rows, test.columns...)
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, buf.String())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rows := []user{{1, "Kate", 25, 9.5, true, time.Date(1998, 5, 1, 0, 0, 0, 0, time.UTC), ""}}
+
+	var buf bytes.Buffer
+	require.NoError(t, csv.Write(stdcsv.NewWriter(&buf), rows))
+
+	result, err := csv.Read[user](stdcsv.NewReader(&buf))
+	require.NoError(t, err)
+	require.Equal(t, rows, result)
+}
+
+func TestPaddedStrings(t *testing.T) {
+	rows := []user{{1, "  padded ", 25, 9.5, true, time.Date(1998, 5, 1, 0, 0, 0, 0, time.UTC), ""}}
+
+	var buf bytes.Buffer
+	require.NoError(t, csv.Write(stdcsv.NewWriter(&buf), rows))
+
+	result, err := csv.Read[user](stdcsv.NewReader(&buf))
+	require.NoError(t, err)
+	require.Equal(t, rows, result)
+
+	input := "id,name,age,score,active,birthday\n 2 , John ,300, 7 ,false,2006-01-02T00:00:00Z\n"
+
+	_, err = csv.Read[user](stdcsv.NewReader(strings.NewReader(input)))
+	require.ErrorIs(t, err, convert.ErrOverflow)
+
+	input = "id,name,age,score,active,birthday\n 2 , John , 30, 7 , false ,2006-01-02T00:00:00Z\n"
+
+	result, err = csv.Read[user](stdcsv.NewReader(strings.NewReader(input)))
+	require.NoError(t, err)
+	require.Equal(t, []user{{2, " John ", 30, 7, false, time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), ""}}, result)
+}
+
+type optional struct {
+	Name  string     `csv:"name"`
+	Age   *int       `csv:"age"`
+	Score *float64   `csv:"score"`
+	Seen  *time.Time `csv:"seen"`
+}
+
+func TestOptionalFields(t *testing.T) {
+	age, score := 25, 9.5
+	seen := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	rows := []optional{
+		{"Kate", &age, &score, &seen},
+		{"John", nil, nil, nil},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, csv.Write(stdcsv.NewWriter(&buf), rows))
+	require.Equal(t, "name,age,score,seen\nKate,25,9.5,2024-01-02T00:00:00Z\nJohn,,,\n", buf.String())
+
+	result, err := csv.Read[optional](stdcsv.NewReader(&buf))
+	require.NoError(t, err)
+	require.Equal(t, rows, result)
+
+	result, err = csv.Read[optional](stdcsv.NewReader(strings.NewReader("name,age\nSam, \nKim,x\n")))
+	require.Equal(t, []optional{{"Sam", nil, nil, nil}}, result)
+
+	var rowErr *csv.RowError
+	require.True(t, errors.As(err, &rowErr))
+	require.Equal(t, 3, rowErr.Row)
+}
+
+func TestUnsupportedField(t *testing.T) {
+	type invalid struct {
+		Name  string
+		Tags  []string
+		Count **int
+	}
+
+	var buf bytes.Buffer
+	err := csv.Write(stdcsv.NewWriter(&buf), []invalid{{Name: "Kate"}})
+	require.EqualError(t, err, "csv: field Tags has unsupported type []string")
+	require.Empty(t, buf.String())
+
+	_, err = csv.Read[invalid](stdcsv.NewReader(strings.NewReader("Name\nKate\n")))
+	require.Error(t, err)
+}
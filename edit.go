@@ -0,0 +1,116 @@
+package gofunc
+
+/*
+Returns a collection consisting of the elements of this
+collection followed by the elements of the other collections.
+*/
+func (c *collection[T]) Concat(others ...*collection[T]) *collection[T] {
+	size := len(c.data)
+
+	for _, other := range others {
+		if other != nil {
+			size += len(other.data)
+		}
+	}
+
+	newcollection := New[T](make([]T, 0, size))
+	newcollection.data = append(newcollection.data, c.data...)
+
+	for _, other := range others {
+		if other != nil {
+			newcollection.data = append(newcollection.data, other.data...)
+		}
+	}
+
+	return newcollection
+}
+
+/*
+Returns a collection consisting of the elements of this
+collection followed by the given elements.
+*/
+func (c *collection[T]) Append(elements ...T) *collection[T] {
+	return c.InsertAt(len(c.data), elements...)
+}
+
+/*
+Returns a collection consisting of the given elements
+followed by the elements of this collection.
+*/
+func (c *collection[T]) Prepend(elements ...T) *collection[T] {
+	return c.InsertAt(0, elements...)
+}
+
+/*
+Returns a collection with the given elements inserted before
+the element at index i. An index below zero inserts at the start
+of the collection, an index past its end appends to it.
+*/
+func (c *collection[T]) InsertAt(i int, elements ...T) *collection[T] {
+	return c.Splice(i, 0, elements...)
+}
+
+/*
+Returns a collection without the element at index i.
+If i is out of range, the collection is returned unchanged.
+*/
+func (c *collection[T]) RemoveAt(i int) *collection[T] {
+	if i < 0 || i >= len(c.data) {
+		return New(c.data)
+	}
+
+	return c.Splice(i, 1)
+}
+
+/*
+Returns a collection consisting of the elements
+of this collection that don't match the given condition.
+*/
+func (c *collection[T]) RemoveFunc(filter func(el T) bool) *collection[T] {
+	if filter == nil {
+		return New(c.data)
+	}
+
+	return c.Filter(func(el T) bool { return !filter(el) })
+}
+
+/*
+Returns a collection with the element at index i set to value.
+If i is out of range, the collection is returned unchanged.
+*/
+func (c *collection[T]) SetAt(i int, value T) *collection[T] {
+	newcollection := New[T](c.data)
+
+	if i >= 0 && i < len(newcollection.data) {
+		newcollection.data[i] = value
+	}
+
+	return newcollection
+}
+
+/*
+Returns a collection with deleteCount elements removed starting
+at index start and the given elements inserted in their place.
+Start is clamped to the bounds of the collection, and deleteCount
+is clamped to the number of elements remaining after start.
+*/
+func (c *collection[T]) Splice(start, deleteCount int, elements ...T) *collection[T] {
+	if start > len(c.data) {
+		start = len(c.data)
+	} else if start < 0 {
+		start = 0
+	}
+
+	if deleteCount > len(c.data)-start {
+		deleteCount = len(c.data) - start
+	} else if deleteCount < 0 {
+		deleteCount = 0
+	}
+
+	newcollection := New[T](make([]T, 0, len(c.data)-deleteCount+len(elements)))
+	newcollection.data = append(newcollection.data, c.data[:start]...)
+	newcollection.data = append(newcollection.data, elements...)
+	newcollection.data = append(newcollection.data, c.data[start+deleteCount:]...)
+
+	return newcollection
+}
@@ -0,0 +1,221 @@
+package gofunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		others   []*collection[int]
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2}),
+			others:   []*collection[int]{New([]int{3}), New([]int{4, 5})},
+			expected: New([]int{1, 2, 3, 4, 5}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2}),
+			others:   nil,
+			expected: New([]int{1, 2}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{}),
+			others:   []*collection[int]{nil, New([]int{1})},
+			expected: New([]int{1}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.Concat(test.others...)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	collection := New([]int{1, 2}).Append(3, 4)
+	require.Equal(t, New([]int{1, 2, 3, 4}), collection)
+}
+
+func TestPrepend(t *testing.T) {
+	collection := New([]int{1, 2}).Prepend(3, 4)
+	require.Equal(t, New([]int{3, 4, 1, 2}), collection)
+}
+
+func TestInsertAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		index    int
+		elements []int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			index:    1,
+			elements: []int{7, 8},
+			expected: New([]int{1, 7, 8, 2, 3}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			index:    -5,
+			elements: []int{7},
+			expected: New([]int{7, 1, 2, 3}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3}),
+			index:    10,
+			elements: []int{7},
+			expected: New([]int{1, 2, 3, 7}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.InsertAt(test.index, test.elements...)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		index    int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			index:    1,
+			expected: New([]int{1, 3}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			index:    3,
+			expected: New([]int{1, 2, 3}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3}),
+			index:    -1,
+			expected: New([]int{1, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.RemoveAt(test.index)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestRemoveFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		script   func(int) bool
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			script:   func(el int) bool { return el%2 == 0 },
+			expected: New([]int{1, 3, 5}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			script:   nil,
+			expected: New([]int{1, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.RemoveFunc(test.script)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestSetAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		index    int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			index:    2,
+			expected: New([]int{1, 2, 0}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			index:    3,
+			expected: New([]int{1, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.SetAt(test.index, 0)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestSplice(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *collection[int]
+		start       int
+		deleteCount int
+		elements    []int
+		expected    *collection[int]
+	}{
+		{
+			name:        "test1",
+			input:       New([]int{1, 2, 3, 4, 5}),
+			start:       1,
+			deleteCount: 2,
+			elements:    []int{7, 8, 9},
+			expected:    New([]int{1, 7, 8, 9, 4, 5}),
+		},
+		{
+			name:        "test2",
+			input:       New([]int{1, 2, 3, 4, 5}),
+			start:       3,
+			deleteCount: 10,
+			elements:    nil,
+			expected:    New([]int{1, 2, 3}),
+		},
+		{
+			name:        "test3",
+			input:       New([]int{1, 2, 3}),
+			start:       1,
+			deleteCount: -1,
+			elements:    []int{0},
+			expected:    New([]int{1, 0, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.Splice(test.start, test.deleteCount, test.elements...)
+		require.Equal(t, test.expected, collection)
+	}
+}
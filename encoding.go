@@ -0,0 +1,264 @@
+package gofunc
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// textSeparator separates the elements of a collection in its text form.
+const textSeparator = ","
+
+/*
+Collection is the exported form of a collection, for use as a field
+type in structs that are encoded as JSON, text or gob, such as API
+responses and configuration. Its zero value is an empty collection.
+*/
+type Collection[T comparable] collection[T]
+
+/*
+Returns a copy of the collection in its exported form,
+ready to be stored in a struct field.
+*/
+func (c *collection[T]) ToCollection() Collection[T] {
+	return Collection[T](*New(c.data))
+}
+
+/*
+Returns a copy of the elements as a collection,
+so that the collection methods can be used on them.
+*/
+func (c Collection[T]) Collection() *collection[T] {
+	return New(c.data)
+}
+
+// Implements json.Marshaler.
+func (c Collection[T]) MarshalJSON() ([]byte, error) {
+	return (*collection[T])(&c).MarshalJSON()
+}
+
+// Implements json.Unmarshaler.
+func (c *Collection[T]) UnmarshalJSON(data []byte) error {
+	return (*collection[T])(c).UnmarshalJSON(data)
+}
+
+// Implements encoding.TextMarshaler.
+func (c Collection[T]) MarshalText() ([]byte, error) {
+	return (*collection[T])(&c).MarshalText()
+}
+
+// Implements encoding.TextUnmarshaler.
+func (c *Collection[T]) UnmarshalText(text []byte) error {
+	return (*collection[T])(c).UnmarshalText(text)
+}
+
+// Implements gob.GobEncoder.
+func (c Collection[T]) GobEncode() ([]byte, error) {
+	return (*collection[T])(&c).GobEncode()
+}
+
+// Implements gob.GobDecoder.
+func (c *Collection[T]) GobDecode(data []byte) error {
+	return (*collection[T])(c).GobDecode(data)
+}
+
+/*
+Encodes a collection as a plain JSON array of its elements.
+Implements json.Marshaler.
+*/
+func (c *collection[T]) MarshalJSON() ([]byte, error) {
+	if c.data == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(c.data)
+}
+
+/*
+Decodes a JSON array into a collection, replacing its elements.
+A JSON null leaves the collection unchanged.
+Implements json.Unmarshaler.
+*/
+func (c *collection[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var arr []T
+
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+
+	if arr == nil {
+		arr = make([]T, 0)
+	}
+
+	c.data = arr
+
+	return nil
+}
+
+/*
+Encodes a collection as a comma separated list of its elements.
+Elements must be strings, booleans, numbers or implement
+encoding.TextMarshaler, and strings must not contain commas.
+A single element with empty text is rejected, since its text
+could not be told apart from an empty collection.
+Implements encoding.TextMarshaler.
+*/
+func (c *collection[T]) MarshalText() ([]byte, error) {
+	parts := make([]string, len(c.data))
+
+	for i, value := range c.data {
+		part, err := marshalTextElement(value)
+		if err != nil {
+			return nil, fmt.Errorf("gofunc: element %d: %w", i, err)
+		}
+
+		if strings.Contains(part, textSeparator) {
+			return nil, fmt.Errorf("gofunc: element %d: %q contains separator %q", i, part, textSeparator)
+		}
+
+		parts[i] = part
+	}
+
+	if len(parts) == 1 && parts[0] == "" {
+		return nil, fmt.Errorf("gofunc: element 0: empty text of a single element reads back as an empty collection")
+	}
+
+	return []byte(strings.Join(parts, textSeparator)), nil
+}
+
+/*
+Decodes a comma separated list of elements into a collection,
+replacing its elements. Empty text produces an empty collection.
+Implements encoding.TextUnmarshaler.
+*/
+func (c *collection[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		c.data = make([]T, 0)
+		return nil
+	}
+
+	parts := strings.Split(string(text), textSeparator)
+	arr := make([]T, len(parts))
+
+	for i, part := range parts {
+		if err := unmarshalTextElement(part, &arr[i]); err != nil {
+			return fmt.Errorf("gofunc: element %d: %w", i, err)
+		}
+	}
+
+	c.data = arr
+
+	return nil
+}
+
+/*
+Encodes a collection with encoding/gob.
+Implements gob.GobEncoder.
+*/
+func (c *collection[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(c.data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+/*
+Decodes a collection encoded with GobEncode, replacing its elements.
+Implements gob.GobDecoder.
+*/
+func (c *collection[T]) GobDecode(data []byte) error {
+	var arr []T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&arr); err != nil {
+		return err
+	}
+
+	if arr == nil {
+		arr = make([]T, 0)
+	}
+
+	c.data = arr
+
+	return nil
+}
+
+func marshalTextElement(value any) (string, error) {
+	if marshaler, ok := value.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("type %T has no text form", value)
+}
+
+func unmarshalTextElement(text string, target any) error {
+	if unmarshaler, ok := target.(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(text))
+	}
+
+	v := reflect.ValueOf(target).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(parsed)
+	default:
+		return fmt.Errorf("type %s has no text form", v.Type())
+	}
+
+	return nil
+}
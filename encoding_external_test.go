@@ -0,0 +1,53 @@
+package gofunc_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/kdl-dev/gofunc"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	Status string
+	Items  gofunc.Collection[int]
+	Tags   gofunc.Collection[string] `json:"tags"`
+}
+
+func TestCollectionField(t *testing.T) {
+	input := response{
+		Status: "ok",
+		Items:  gofunc.New([]int{1, 2, 3}).ToCollection(),
+		Tags:   gofunc.New([]string{"a", "b"}).ToCollection(),
+	}
+
+	data, err := json.Marshal(input)
+	require.NoError(t, err)
+	require.Equal(t, `{"Status":"ok","Items":[1,2,3],"tags":["a","b"]}`, string(data))
+
+	var fromJSON response
+	require.NoError(t, json.Unmarshal(data, &fromJSON))
+	require.Equal(t, []int{1, 2, 3}, fromJSON.Items.Collection().ToSlice())
+	require.Equal(t, []string{"a", "b"}, fromJSON.Tags.Collection().ToSlice())
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(input))
+
+	var fromGob response
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&fromGob))
+	require.Equal(t, "ok", fromGob.Status)
+	require.Equal(t, []int{1, 2, 3}, fromGob.Items.Collection().ToSlice())
+	require.Equal(t, []string{"a", "b"}, fromGob.Tags.Collection().ToSlice())
+
+	var empty response
+	data, err = json.Marshal(empty)
+	require.NoError(t, err)
+	require.Equal(t, `{"Status":"","Items":[],"tags":[]}`, string(data))
+	require.Equal(t, 0, empty.Items.Collection().Len())
+
+	text, err := input.Items.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "1,2,3", string(text))
+}
@@ -0,0 +1,188 @@
+package gofunc
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			expected: `[1,2,3]`,
+		},
+		{
+			name:     "test2",
+			input:    New([]string{}),
+			expected: `[]`,
+		},
+		{
+			name:     "test3",
+			input:    struct{ Items any }{New([]string{"a", "b"})},
+			expected: `{"Items":["a","b"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		data, err := json.Marshal(test.input)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, string(data))
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *collection[int]
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			input:    `[1,2,3]`,
+			expected: New([]int{1, 2, 3}),
+		},
+		{
+			name:     "test2",
+			input:    `[]`,
+			expected: New([]int{}),
+		},
+		{
+			name:     "test3",
+			input:    `null`,
+			expected: New([]int{7}),
+		},
+		{
+			name:    "test4",
+			input:   `{"a":1}`,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := New([]int{7})
+		err := json.Unmarshal([]byte(test.input), collection)
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{ MarshalText() ([]byte, error) }
+		expected string
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			expected: "1,2,3",
+		},
+		{
+			name:     "test2",
+			input:    New([]float64{1.5, -2}),
+			expected: "1.5,-2",
+		},
+		{
+			name:    "test3",
+			input:   New([]string{"a,b"}),
+			isError: true,
+		},
+		{
+			name:    "test4",
+			input:   New([]complex128{1i}),
+			isError: true,
+		},
+		{
+			name:    "test5",
+			input:   New([]string{""}),
+			isError: true,
+		},
+		{
+			name:     "test6",
+			input:    New([]string{"", ""}),
+			expected: ",",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		text, err := test.input.MarshalText()
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, string(text))
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *collection[int8]
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			input:    "1,2,3",
+			expected: New([]int8{1, 2, 3}),
+		},
+		{
+			name:     "test2",
+			input:    "",
+			expected: New([]int8{}),
+		},
+		{
+			name:    "test3",
+			input:   "1,300",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := New([]int8{})
+		err := collection.UnmarshalText([]byte(test.input))
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestGob(t *testing.T) {
+	var buf bytes.Buffer
+
+	input := New([]string{"a", "b", "c"})
+	require.NoError(t, gob.NewEncoder(&buf).Encode(input))
+
+	output := New([]string{})
+	require.NoError(t, gob.NewDecoder(&buf).Decode(output))
+	require.Equal(t, input, output)
+}
@@ -0,0 +1,80 @@
+package expr
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+A function callable from expressions. Arguments are checked against
+params, the accepted kinds of each argument, before call sees them.
+*/
+type builtin struct {
+	params [][]kind
+	result kind
+	call   func(pos int, args []any) (any, error)
+}
+
+var builtins = map[string]builtin{
+	"len": {
+		params: [][]kind{{kindString, kindList, kindObject}},
+		result: kindInt,
+		call: func(pos int, args []any) (any, error) {
+			switch v := args[0].(type) {
+			case string:
+				return int64(utf8.RuneCountInString(v)), nil
+			case []any:
+				return int64(len(v)), nil
+			case reflect.Value:
+				if v.Kind() == reflect.Map {
+					return int64(v.Len()), nil
+				}
+			}
+
+			return nil, newError(pos, ErrType, "len is not defined on %v", kindOfValue(args[0]))
+		},
+	},
+	"lower":      stringFunc(strings.ToLower),
+	"upper":      stringFunc(strings.ToUpper),
+	"trim":       stringFunc(strings.TrimSpace),
+	"contains":   stringPredicate(strings.Contains),
+	"startsWith": stringPredicate(strings.HasPrefix),
+	"endsWith":   stringPredicate(strings.HasSuffix),
+	"abs": {
+		params: [][]kind{{kindInt, kindFloat}},
+		result: kindAny,
+		call: func(pos int, args []any) (any, error) {
+			if i, isInt := args[0].(int64); isInt {
+				if i < 0 {
+					return -i, nil
+				}
+
+				return i, nil
+			}
+
+			return math.Abs(args[0].(float64)), nil
+		},
+	},
+}
+
+func stringFunc(f func(s string) string) builtin {
+	return builtin{
+		params: [][]kind{{kindString}},
+		result: kindString,
+		call: func(pos int, args []any) (any, error) {
+			return f(args[0].(string)), nil
+		},
+	}
+}
+
+func stringPredicate(f func(s, substr string) bool) builtin {
+	return builtin{
+		params: [][]kind{{kindString}, {kindString}},
+		result: kindBool,
+		call: func(pos int, args []any) (any, error) {
+			return f(args[0].(string), args[1].(string)), nil
+		},
+	}
+}
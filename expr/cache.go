@@ -0,0 +1,67 @@
+package expr
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+)
+
+// maxPrograms bounds the number of compiled programs kept by Compile.
+const maxPrograms = 1024
+
+type cacheKey struct {
+	t      reflect.Type
+	source string
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	root *node
+}
+
+/*
+programCache keeps up to size compiled programs,
+evicting the least recently used one first.
+*/
+type programCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[cacheKey]*list.Element
+}
+
+func newProgramCache(size int) *programCache {
+	return &programCache{size: size, order: list.New(), entries: make(map[cacheKey]*list.Element)}
+}
+
+func (c *programCache) load(key cacheKey) (*node, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, isCached := c.entries[key]
+	if !isCached {
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return el.Value.(*cacheEntry).root, true
+}
+
+func (c *programCache) store(key cacheKey, root *node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, isCached := c.entries[key]; isCached {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key, root})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
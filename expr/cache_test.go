@@ -0,0 +1,34 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramCache(t *testing.T) {
+	c := newProgramCache(2)
+	t1 := reflect.TypeOf(0)
+	first, second, third := &node{}, &node{}, &node{}
+
+	c.store(cacheKey{t1, "a"}, first)
+	c.store(cacheKey{t1, "b"}, second)
+
+	root, isCached := c.load(cacheKey{t1, "a"})
+	require.True(t, isCached)
+	require.Same(t, first, root)
+
+	c.store(cacheKey{t1, "c"}, third)
+
+	_, isCached = c.load(cacheKey{t1, "b"})
+	require.False(t, isCached)
+
+	root, isCached = c.load(cacheKey{t1, "a"})
+	require.True(t, isCached)
+	require.Same(t, first, root)
+
+	_, isCached = c.load(cacheKey{reflect.TypeOf(""), "a"})
+	require.False(t, isCached)
+	require.Equal(t, 2, c.order.Len())
+}
@@ -0,0 +1,552 @@
+package expr
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxLength = 4096
+	maxDepth  = 64
+)
+
+/*
+A compiled expression. typ is the Go type of the value when it is known
+at compile time, and kind the kind of value it evaluates to, kindAny
+when it is only known at run time.
+*/
+type node struct {
+	pos  int
+	kind kind
+	typ  reflect.Type
+	eval func(env reflect.Value) (any, error)
+}
+
+type parser struct {
+	tokens []token
+	i      int
+	depth  int
+	root   *node
+}
+
+func compile(t reflect.Type, source string) (*node, error) {
+	if len(source) > maxLength {
+		return nil, newError(0, ErrSyntax, "expression is longer than %d bytes", maxLength)
+	}
+
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	p.root = &node{
+		kind: kindOfType(t),
+		typ:  staticType(t),
+		eval: func(env reflect.Value) (any, error) {
+			return normalize(env), nil
+		},
+	}
+
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, unexpected(tok, "end of expression")
+	}
+
+	return n, nil
+}
+
+// Returns t, or nil when values of type t can hold anything.
+func staticType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		return nil
+	}
+
+	return t
+}
+
+func unexpected(tok token, what string) error {
+	return newError(tok.pos, ErrSyntax, "unexpected %v, expected %s", tok, what)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokenEOF {
+		p.i++
+	}
+
+	return tok
+}
+
+func (p *parser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokenPunct && tok.text == text
+}
+
+func (p *parser) expectPunct(text string) error {
+	if tok := p.next(); tok.kind != tokenPunct || tok.text != text {
+		return unexpected(tok, strconv.Quote(text))
+	}
+
+	return nil
+}
+
+func (p *parser) enter(pos int) error {
+	if p.depth++; p.depth > maxDepth {
+		return newError(pos, ErrSyntax, "expression is nested deeper than %d levels", maxDepth)
+	}
+
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseExpr() (*node, error) {
+	if err := p.enter(p.peek().pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	return p.parseBinary(1)
+}
+
+/*
+Returns the binary operator at the current token, its precedence level
+and the number of tokens it takes, or a zero level when there is none.
+*/
+func (p *parser) binaryOperator() (string, int, int) {
+	tok := p.peek()
+
+	switch {
+	case tok.kind == tokenKeyword && tok.text == "in":
+		return "in", 3, 1
+	case tok.kind != tokenOperator:
+		return "", 0, 0
+	}
+
+	switch tok.text {
+	case "||":
+		return tok.text, 1, 1
+	case "&&":
+		return tok.text, 2, 1
+	case "==", "!=", "<", "<=", ">", ">=":
+		return tok.text, 3, 1
+	case "+", "-":
+		return tok.text, 4, 1
+	case "*", "/", "%":
+		return tok.text, 5, 1
+	case "!":
+		if next := p.tokens[p.i+1]; next.kind == tokenKeyword && next.text == "in" {
+			return "not in", 3, 2
+		}
+	}
+
+	return "", 0, 0
+}
+
+func (p *parser) parseBinary(minLevel int) (*node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, level, width := p.binaryOperator()
+		if level < minLevel || level == 0 {
+			return left, nil
+		}
+
+		pos := p.peek().pos
+		p.i += width
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if left, err = combine(pos, op, left, right); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func combine(pos int, op string, left, right *node) (*node, error) {
+	base := strings.TrimPrefix(op, "not ")
+
+	k, isDefined := binaryKind(base, left.kind, right.kind)
+	if !isDefined {
+		return nil, newError(pos, ErrType, "operator %s is not defined on %v and %v", op, left.kind, right.kind)
+	}
+
+	n := &node{pos: pos, kind: k}
+
+	switch op {
+	case "&&", "||":
+		n.eval = func(env reflect.Value) (any, error) {
+			l, err := truth(pos, left, env)
+			if err != nil || l == (op == "||") {
+				return l, err
+			}
+
+			return truth(pos, right, env)
+		}
+	default:
+		n.eval = func(env reflect.Value) (any, error) {
+			l, err := left.eval(env)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := right.eval(env)
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := binary(pos, base, l, r)
+			if err != nil || base == op {
+				return v, err
+			}
+
+			return !v.(bool), nil
+		}
+	}
+
+	return n, nil
+}
+
+func truth(pos int, n *node, env reflect.Value) (bool, error) {
+	v, err := n.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	b, isBool := v.(bool)
+	if !isBool {
+		return false, newError(pos, ErrType, "expected bool, got %v", kindOfValue(v))
+	}
+
+	return b, nil
+}
+
+func (p *parser) parseUnary() (*node, error) {
+	tok := p.peek()
+	if tok.kind != tokenOperator || tok.text != "!" && tok.text != "-" {
+		return p.parsePostfix()
+	}
+
+	p.next()
+
+	if err := p.enter(tok.pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.text == "!" {
+		if !accepts(operand.kind, kindBool) {
+			return nil, newError(tok.pos, ErrType, "operator ! is not defined on %v", operand.kind)
+		}
+
+		return &node{pos: tok.pos, kind: kindBool, eval: func(env reflect.Value) (any, error) {
+			b, err := truth(tok.pos, operand, env)
+			return !b, err
+		}}, nil
+	}
+
+	if !accepts(operand.kind, kindInt, kindFloat) {
+		return nil, newError(tok.pos, ErrType, "operator - is not defined on %v", operand.kind)
+	}
+
+	return &node{pos: tok.pos, kind: operand.kind, eval: func(env reflect.Value) (any, error) {
+		v, err := operand.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := v.(type) {
+		case int64:
+			return -v, nil
+		case float64:
+			return -v, nil
+		}
+
+		return nil, newError(tok.pos, ErrType, "operator - is not defined on %v", kindOfValue(v))
+	}}, nil
+}
+
+func (p *parser) parsePostfix() (*node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.isPunct("."):
+			p.next()
+
+			tok := p.next()
+			if tok.kind != tokenIdent {
+				return nil, unexpected(tok, "a field name")
+			}
+
+			if n, err = selectField(n, tok); err != nil {
+				return nil, err
+			}
+		case p.isPunct("["):
+			pos := p.next().pos
+
+			i, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expectPunct("]"); err != nil {
+				return nil, err
+			}
+
+			if n, err = indexNode(pos, n, i); err != nil {
+				return nil, err
+			}
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (*node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		text := strings.ReplaceAll(tok.text, "_", "")
+
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return literal(tok.pos, i), nil
+		}
+
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, newError(tok.pos, ErrSyntax, "invalid number %s", tok.text)
+		}
+
+		return literal(tok.pos, f), nil
+	case tokenString:
+		return literal(tok.pos, tok.text), nil
+	case tokenKeyword:
+		switch tok.text {
+		case "true", "false":
+			return literal(tok.pos, tok.text == "true"), nil
+		case "nil":
+			return literal(tok.pos, nil), nil
+		}
+	case tokenIdent:
+		if p.isPunct("(") {
+			return p.parseCall(tok)
+		}
+
+		return selectField(p.root, tok)
+	case tokenPunct:
+		switch tok.text {
+		case "(":
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+
+			return n, p.expectPunct(")")
+		case "[":
+			return p.parseList(tok.pos)
+		}
+	}
+
+	return nil, unexpected(tok, "a value")
+}
+
+func literal(pos int, v any) *node {
+	return &node{pos: pos, kind: kindOfValue(v), eval: func(env reflect.Value) (any, error) {
+		return v, nil
+	}}
+}
+
+func (p *parser) parseList(pos int) (*node, error) {
+	elements, err := p.parseSequence("]")
+	if err != nil {
+		return nil, err
+	}
+
+	return &node{pos: pos, kind: kindList, eval: func(env reflect.Value) (any, error) {
+		return evalAll(elements, env)
+	}}, nil
+}
+
+// Parses the arguments of a call or the elements of a list up to end.
+func (p *parser) parseSequence(end string) ([]*node, error) {
+	nodes := make([]*node, 0)
+
+	if p.isPunct(end) {
+		p.next()
+		return nodes, nil
+	}
+
+	for {
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+
+		tok := p.next()
+		if tok.kind == tokenPunct && tok.text == end {
+			return nodes, nil
+		} else if tok.kind != tokenPunct || tok.text != "," {
+			return nil, unexpected(tok, strconv.Quote(",")+" or "+strconv.Quote(end))
+		}
+	}
+}
+
+func evalAll(nodes []*node, env reflect.Value) ([]any, error) {
+	values := make([]any, len(nodes))
+
+	for i, n := range nodes {
+		v, err := n.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func (p *parser) parseCall(name token) (*node, error) {
+	p.next()
+
+	b, isFound := builtins[name.text]
+	if !isFound {
+		return nil, newError(name.pos, ErrUnknownName, "unknown function %s", name.text)
+	}
+
+	args, err := p.parseSequence(")")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) != len(b.params) {
+		return nil, newError(name.pos, ErrType, "%s takes %d arguments, got %d", name.text, len(b.params), len(args))
+	}
+
+	for i, arg := range args {
+		if !accepts(arg.kind, b.params[i]...) {
+			return nil, newError(arg.pos, ErrType, "cannot use %v as argument %d of %s", arg.kind, i+1, name.text)
+		}
+	}
+
+	return &node{pos: name.pos, kind: b.result, eval: func(env reflect.Value) (any, error) {
+		values, err := evalAll(args, env)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, v := range values {
+			if !accepts(kindOfValue(v), b.params[i]...) {
+				return nil, newError(args[i].pos, ErrType, "cannot use %v as argument %d of %s", kindOfValue(v), i+1, name.text)
+			}
+		}
+
+		return b.call(name.pos, values)
+	}}, nil
+}
+
+/*
+Returns a node selecting the field or map entry named by tok from n.
+Fields of structs are resolved at compile time.
+*/
+func selectField(n *node, tok token) (*node, error) {
+	name := tok.text
+
+	var typ reflect.Type
+
+	if t := n.typ; t != nil {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch {
+		case t.Kind() == reflect.Struct:
+			sf, isFound := lookupField(t, name)
+			if !isFound {
+				return nil, newError(tok.pos, ErrUnknownName, "%v has no field %s", t, name)
+			}
+
+			typ = staticType(sf.Type)
+		case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+			typ = staticType(t.Elem())
+		default:
+			return nil, newError(tok.pos, ErrType, "cannot select %s from %v", name, t)
+		}
+	} else if !accepts(n.kind, kindObject) {
+		return nil, newError(tok.pos, ErrType, "cannot select %s from %v", name, n.kind)
+	}
+
+	return &node{pos: tok.pos, kind: kindOfType(typ), typ: typ, eval: func(env reflect.Value) (any, error) {
+		v, err := n.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		return member(tok.pos, v, name)
+	}}, nil
+}
+
+func indexNode(pos int, n, i *node) (*node, error) {
+	var typ reflect.Type
+
+	if t := n.typ; t != nil {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch {
+		case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && accepts(i.kind, kindInt):
+			typ = staticType(t.Elem())
+		case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && accepts(i.kind, kindString):
+			typ = staticType(t.Elem())
+		default:
+			return nil, newError(pos, ErrType, "cannot index %v with %v", t, i.kind)
+		}
+	} else if !accepts(n.kind, kindList, kindObject) {
+		return nil, newError(pos, ErrType, "cannot index %v with %v", n.kind, i.kind)
+	}
+
+	return &node{pos: pos, kind: kindOfType(typ), typ: typ, eval: func(env reflect.Value) (any, error) {
+		v, err := n.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := i.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		return index(pos, v, key)
+	}}, nil
+}
@@ -0,0 +1,180 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrSyntax      = errors.New("syntax error")
+	ErrType        = errors.New("type error")
+	ErrUnknownName = errors.New("unknown name")
+	ErrRuntime     = errors.New("runtime error")
+)
+
+/*
+Error reports a failure to compile or evaluate an expression at a byte
+offset of its source. Err is one of ErrSyntax, ErrType, ErrUnknownName
+and ErrRuntime.
+*/
+type Error struct {
+	Pos int
+	Err error
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("expr: position %d: %v: %s", e.Pos+1, e.Err, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(pos int, err error, format string, args ...any) *Error {
+	return &Error{Pos: pos, Err: err, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Program is an expression compiled against values of type T.
+type Program[T any] struct {
+	source string
+	root   *node
+}
+
+var programs = newProgramCache(maxPrograms)
+
+/*
+Returns the compiled form of an expression such as
+
+	price * qty > 100 && status in ["open", "hold"]
+
+evaluated against values of type T, which are structs, maps with string
+keys or pointers to them. Identifiers name struct fields, by Go name,
+json tag name or Go name ignoring case, or map entries; nested values
+are reached with a.b and a["b"], and list elements with a[0].
+The language has int, float, string (in single or double quotes), bool,
+nil and list ([...]) values, the operators || && ! (also written or,
+and, not), == != < <= > >= in and not in, + - * / % and unary -, and
+the functions len, lower, upper, trim, contains, startsWith, endsWith
+and abs. / always divides as floats.
+
+Evaluation is sandboxed: it only reads exported fields and map entries,
+never calls methods, and the length and nesting of an expression are
+limited, so expressions can come from untrusted sources. Types are
+checked at compile time wherever the Go types are known, and at run
+time otherwise. The most recently used compiled programs are cached
+by type and source.
+Errors are returned as *Error.
+*/
+func Compile[T any](source string) (*Program[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	key := cacheKey{t, source}
+
+	if root, isCached := programs.load(key); isCached {
+		return &Program[T]{source: source, root: root}, nil
+	}
+
+	root, err := compile(t, source)
+	if err != nil {
+		return nil, err
+	}
+
+	programs.store(key, root)
+
+	return &Program[T]{source: source, root: root}, nil
+}
+
+// Returns the compiled form of the expression, and panics if it is invalid.
+func MustCompile[T any](source string) *Program[T] {
+	p, err := Compile[T](source)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func (p *Program[T]) String() string {
+	return p.source
+}
+
+// Returns the value of the expression for el.
+func (p *Program[T]) Eval(el T) (any, error) {
+	v, err := p.root.eval(reflect.ValueOf(&el).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	return export(v), nil
+}
+
+// Returns the value of a boolean expression for el.
+func (p *Program[T]) Bool(el T) (bool, error) {
+	v, err := p.root.eval(reflect.ValueOf(&el).Elem())
+	if err != nil {
+		return false, err
+	}
+
+	b, isBool := v.(bool)
+	if !isBool {
+		return false, newError(p.root.pos, ErrType, "expression is %v, not bool", kindOfValue(v))
+	}
+
+	return b, nil
+}
+
+/*
+Returns a filter compiled from a boolean expression, ready to be passed
+to Filter, Match or AllMatch. Elements for which the evaluation fails
+are treated as not matching; use Program.Bool to see the error.
+*/
+func Predicate[T any](source string) (func(el T) bool, error) {
+	p, err := Compile[T](source)
+	if err != nil {
+		return nil, err
+	}
+
+	if !accepts(p.root.kind, kindBool) {
+		return nil, newError(p.root.pos, ErrType, "expression is %v, not bool", p.root.kind)
+	}
+
+	return func(el T) bool {
+		b, err := p.Bool(el)
+		return err == nil && b
+	}, nil
+}
+
+/*
+Returns a function compiled from an expression whose value is converted
+to V, ready to be passed to Map or convert.New. Numbers convert to any
+numeric V, floats being truncated toward zero for integer types.
+Elements for which the evaluation fails, or whose value is out of the
+range of V, give the zero value of V; use Program.Eval to see the value.
+*/
+func Func[T, V any](source string) (func(el T) V, error) {
+	p, err := Compile[T](source)
+	if err != nil {
+		return nil, err
+	}
+
+	vt := reflect.TypeOf((*V)(nil)).Elem()
+	k, vk := p.root.kind, kindOfType(vt)
+
+	if vt.Kind() != reflect.Interface && k != kindAny && k != kindNil &&
+		k != vk && !(isNumber(k) && isNumber(vk)) {
+		return nil, newError(p.root.pos, ErrType, "expression is %v, not %v", k, vt)
+	}
+
+	return func(el T) V {
+		v, err := p.root.eval(reflect.ValueOf(&el).Elem())
+		if err != nil {
+			var zero V
+			return zero
+		}
+
+		result, _ := convertTo[V](v)
+
+		return result
+	}, nil
+}
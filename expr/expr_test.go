@@ -0,0 +1,314 @@
+package expr_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kdl-dev/gofunc"
+	"github.com/kdl-dev/gofunc/expr"
+	"github.com/stretchr/testify/require"
+)
+
+type customer struct {
+	Name string
+	Vip  bool
+}
+
+type order struct {
+	Id       int
+	Price    float64
+	Qty      uint
+	Status   string `json:"status"`
+	Tags     []string
+	Customer *customer
+	Extra    map[string]any
+}
+
+var orders = []order{
+	{1, 25, 4, "open", []string{"gift"}, &customer{"Kate", true}, map[string]any{"channel": "web"}},
+	{2, 10, 3, "hold", nil, &customer{"John", false}, nil},
+	{3, 99.5, 2, "closed", []string{"gift", "fragile"}, nil, map[string]any{"channel": "shop"}},
+	{4, 120, 1, "hold", []string{}, &customer{"Kim", true}, map[string]any{"channel": "web"}},
+}
+
+var pointers = []*order{&orders[0], &orders[1], &orders[2], &orders[3]}
+
+func ids(c interface{ ToSlice() []*order }) []int {
+	result := make([]int, 0)
+	for _, el := range c.ToSlice() {
+		result = append(result, el.Id)
+	}
+
+	return result
+}
+
+func TestPredicate(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []int
+	}{
+		{
+			name:     "test1",
+			source:   `price * qty > 100 && status in ["open","hold"]`,
+			expected: []int{4},
+		},
+		{
+			name:     "test2",
+			source:   `Price * Qty >= 30 and not (Status == 'closed')`,
+			expected: []int{1, 2, 4},
+		},
+		{
+			name:     "test3",
+			source:   `"gift" in tags || Customer.Name == "John"`,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "test4",
+			source:   `customer.vip && extra.channel == "web" && len(tags) == 0`,
+			expected: []int{4},
+		},
+		{
+			name:     "test5",
+			source:   `customer == nil || startsWith(lower(Customer.Name), "k") && Id % 2 == 1`,
+			expected: []int{1, 3},
+		},
+		{
+			name:     "test6",
+			source:   `status not in ["hold"] && Extra["channel"] != "web"`,
+			expected: []int{3},
+		},
+		{
+			name:     "test7",
+			source:   `Price / Qty > 10 && Tags[0] == "gift"`,
+			expected: []int{3},
+		},
+		{
+			name:     "test8",
+			source:   `-Price < -50 || abs(Id - 3) == 1 && contains(Status, "o")`,
+			expected: []int{2, 3, 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		filter, err := expr.Predicate[*order](test.source)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, ids(gofunc.New(pointers).Filter(filter)))
+	}
+}
+
+func TestPredicateMap(t *testing.T) {
+	rows := []map[string]any{
+		{"price": 25.0, "qty": 4, "status": "open"},
+		{"price": 200, "qty": 1, "status": "hold"},
+		{"price": "n/a", "qty": 1, "status": "open"},
+		{"status": "open"},
+	}
+
+	filter, err := expr.Predicate[map[string]any](`price * qty > 100 && status in ["open","hold"]`)
+	require.NoError(t, err)
+
+	matched := make([]int, 0)
+	for i, row := range rows {
+		if filter(row) {
+			matched = append(matched, i)
+		}
+	}
+
+	require.Equal(t, []int{1}, matched)
+
+	p := expr.MustCompile[map[string]any](`price * qty > 100`)
+
+	_, err = p.Bool(rows[2])
+	require.True(t, errors.Is(err, expr.ErrType))
+	require.EqualError(t, err, "expr: position 7: type error: operator * is not defined on string and int")
+
+	_, err = p.Bool(rows[3])
+	require.True(t, errors.Is(err, expr.ErrType))
+}
+
+func TestFunc(t *testing.T) {
+	total, err := expr.Func[order, float64](`Price * Qty`)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, total(orders[0]))
+
+	count, err := expr.Func[*order, int](`len(Tags) + Id`)
+	require.NoError(t, err)
+	require.Equal(t, 5, count(&orders[2]))
+
+	label, err := expr.Func[order, string](`upper(status) + ":" + Customer.Name`)
+	require.NoError(t, err)
+	require.Equal(t, "OPEN:Kate", label(orders[0]))
+	require.Equal(t, "", label(orders[2]))
+
+	list, err := expr.Func[order, any](`[Id, trim(" x "), Customer.Vip]`)
+	require.NoError(t, err)
+	require.Equal(t, []any{int64(1), "x", true}, list(orders[0]))
+
+	p := expr.MustCompile[order](`Customer`)
+	v, err := p.Eval(orders[1])
+	require.NoError(t, err)
+	require.Equal(t, customer{"John", false}, v)
+	require.Equal(t, "Customer", p.String())
+
+	narrow, err := expr.Func[order, int8](`Id * 100`)
+	require.NoError(t, err)
+	require.Equal(t, int8(100), narrow(orders[0]))
+	require.Equal(t, int8(0), narrow(orders[1]))
+
+	unsigned, err := expr.Func[order, uint8](`Id - 2`)
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), unsigned(orders[2]))
+	require.Equal(t, uint8(0), unsigned(orders[0]))
+
+	truncated, err := expr.Func[order, int16](`Price * Qty * 1000`)
+	require.NoError(t, err)
+	require.Equal(t, int16(0), truncated(orders[0]))
+
+	ratio, err := expr.Func[order, int16](`Price / Qty`)
+	require.NoError(t, err)
+	require.Equal(t, int16(6), ratio(orders[0]))
+
+	_, err = expr.Func[order, bool](`Price * 2`)
+	require.True(t, errors.Is(err, expr.ErrType))
+}
+
+func TestNaN(t *testing.T) {
+	nan := order{Id: 5, Price: math.NaN()}
+
+	tests := []struct {
+		source   string
+		expected bool
+	}{
+		{`Price == 5`, false},
+		{`Price != 5`, true},
+		{`Price < 100`, false},
+		{`Price <= 100`, false},
+		{`Price > 0`, false},
+		{`Price >= 100`, false},
+		{`Price == Price`, false},
+		{`Price in [5, 100]`, false},
+		{`Price not in [5, 100]`, true},
+		{`Id >= 5`, true},
+	}
+
+	for _, test := range tests {
+		t.Log(test.source)
+
+		b, err := expr.MustCompile[order](test.source).Bool(nan)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, b)
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		err      error
+		expected string
+	}{
+		{
+			name:     "test1",
+			source:   `price * qty > 100 && stauts == "open"`,
+			err:      expr.ErrUnknownName,
+			expected: "expr: position 22: unknown name: expr_test.order has no field stauts",
+		},
+		{
+			name:     "test2",
+			source:   `Status > 1`,
+			err:      expr.ErrType,
+			expected: "expr: position 8: type error: operator > is not defined on string and int",
+		},
+		{
+			name:     "test3",
+			source:   `Price * (Qty + 1`,
+			err:      expr.ErrSyntax,
+			expected: "expr: position 17: syntax error: unexpected end of expression, expected \")\"",
+		},
+		{
+			name:     "test4",
+			source:   `Status = "open"`,
+			err:      expr.ErrSyntax,
+			expected: "expr: position 8: syntax error: unexpected \"=\", expected \"==\"",
+		},
+		{
+			name:     "test5",
+			source:   `exec("rm -rf /")`,
+			err:      expr.ErrUnknownName,
+			expected: "expr: position 1: unknown name: unknown function exec",
+		},
+		{
+			name:     "test6",
+			source:   `lower(Price)`,
+			err:      expr.ErrType,
+			expected: "expr: position 7: type error: cannot use float as argument 1 of lower",
+		},
+		{
+			name:     "test7",
+			source:   `Price`,
+			err:      expr.ErrType,
+			expected: "expr: position 1: type error: expression is float, not bool",
+		},
+		{
+			name:     "test8",
+			source:   `Status.Name == "x"`,
+			err:      expr.ErrType,
+			expected: "expr: position 8: type error: cannot select Name from string",
+		},
+		{
+			name:     "test9",
+			source:   `Status == 'open`,
+			err:      expr.ErrSyntax,
+			expected: "expr: position 11: syntax error: unterminated string",
+		},
+		{
+			name:     "test10",
+			source:   `!Id`,
+			err:      expr.ErrType,
+			expected: "expr: position 1: type error: operator ! is not defined on int",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		_, err := expr.Predicate[order](test.source)
+		require.True(t, errors.Is(err, test.err))
+		require.EqualError(t, err, test.expected)
+
+		var exprErr *expr.Error
+		require.True(t, errors.As(err, &exprErr))
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	deep := ""
+	for i := 0; i < 100; i++ {
+		deep += "("
+	}
+
+	_, err := expr.Compile[order](deep + "true")
+	require.True(t, errors.Is(err, expr.ErrSyntax))
+
+	long := "Id"
+	for len(long) < 5000 {
+		long += " + Id"
+	}
+
+	_, err = expr.Compile[order](long)
+	require.True(t, errors.Is(err, expr.ErrSyntax))
+
+	p := expr.MustCompile[order](`Tags[5] == "x" || 1 / (Id - 1) > 0`)
+
+	_, err = p.Bool(orders[0])
+	require.True(t, errors.Is(err, expr.ErrRuntime))
+	require.EqualError(t, err, "expr: position 5: runtime error: index 5 out of range [0, 1)")
+
+	first := expr.MustCompile[order](`Id == 1`)
+	second := expr.MustCompile[order](`Id == 1`)
+	require.Equal(t, first, second)
+}
@@ -0,0 +1,154 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenKeyword
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var keywords = map[string]string{
+	"true": "true", "false": "false", "nil": "nil", "in": "in",
+	"not": "!", "and": "&&", "or": "||",
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+func lex(input string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.ContainsRune("()[],.", r):
+			tokens = append(tokens, token{tokenPunct, string(r), i})
+			i++
+		case strings.ContainsRune("+-*/%=!<>&|", r):
+			op := input[i : i+1]
+			if i+1 < len(input) {
+				switch two := input[i : i+2]; two {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					op = two
+				}
+			}
+
+			if op == "=" || op == "&" || op == "|" {
+				return nil, newError(i, ErrSyntax, "unexpected %q, expected %q", op, op+op)
+			}
+
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		case r == '"' || r == '\'':
+			text, n, err := lexString(input[i:])
+			if err != nil {
+				return nil, newError(i, ErrSyntax, "%v", err)
+			}
+
+			tokens = append(tokens, token{tokenString, text, i})
+			i += n
+		case unicode.IsDigit(r):
+			j := i + 1
+			for j < len(input) && (isDigit(input[j]) || input[j] == '_' ||
+				input[j] == '.' && j+1 < len(input) && isDigit(input[j+1])) {
+				j++
+			}
+
+			tokens = append(tokens, token{tokenNumber, input[i:j], i})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + size
+			for j < len(input) {
+				next, n := utf8.DecodeRuneInString(input[j:])
+				if !unicode.IsLetter(next) && !unicode.IsDigit(next) && next != '_' {
+					break
+				}
+
+				j += n
+			}
+
+			text := input[i:j]
+			if keyword, isKeyword := keywords[text]; isKeyword && keyword != text {
+				tokens = append(tokens, token{tokenOperator, keyword, i})
+			} else if isKeyword {
+				tokens = append(tokens, token{tokenKeyword, keyword, i})
+			} else {
+				tokens = append(tokens, token{tokenIdent, text, i})
+			}
+
+			i = j
+		default:
+			return nil, newError(i, ErrSyntax, "unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, token{tokenEOF, "", len(input)}), nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+/*
+Reads a quoted string at the start of s and returns its value and the
+number of bytes it took. Both quote characters are accepted, and a
+backslash escapes the quote, the backslash itself, \n and \t.
+*/
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+
+	var sb strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\\' && i+1 < len(s):
+			i++
+
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '\'':
+				sb.WriteByte(s[i])
+			default:
+				return "", 0, fmt.Errorf("unknown escape sequence \\%c", s[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string")
+}
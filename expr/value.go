@@ -0,0 +1,548 @@
+package expr
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+/*
+The kinds of values an expression works with. Go values are normalized
+on the way in: all integers become int64, floats become float64, slices
+and arrays become []any, and structs and maps are kept as reflect.Value.
+*/
+type kind int
+
+const (
+	kindAny kind = iota
+	kindNil
+	kindInt
+	kindFloat
+	kindString
+	kindBool
+	kindList
+	kindObject
+)
+
+func (k kind) String() string {
+	return [...]string{"any", "nil", "int", "float", "string", "bool", "list", "object"}[k]
+}
+
+func isNumber(k kind) bool {
+	return k == kindInt || k == kindFloat
+}
+
+// Returns whether k is one of kinds, treating an unknown kind as a match.
+func accepts(k kind, kinds ...kind) bool {
+	if k == kindAny {
+		return true
+	}
+
+	for _, other := range kinds {
+		if k == other {
+			return true
+		}
+	}
+
+	return false
+}
+
+func kindOfType(t reflect.Type) kind {
+	if t == nil {
+		return kindAny
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindInt
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.String:
+		return kindString
+	case reflect.Bool:
+		return kindBool
+	case reflect.Slice, reflect.Array:
+		return kindList
+	case reflect.Struct, reflect.Map:
+		return kindObject
+	}
+
+	return kindAny
+}
+
+func kindOfValue(v any) kind {
+	switch v.(type) {
+	case nil:
+		return kindNil
+	case int64:
+		return kindInt
+	case float64:
+		return kindFloat
+	case string:
+		return kindString
+	case bool:
+		return kindBool
+	case []any:
+		return kindList
+	}
+
+	return kindObject
+}
+
+func normalize(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u)
+		}
+
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Slice, reflect.Array:
+		list := make([]any, v.Len())
+		for i := range list {
+			list[i] = normalize(v.Index(i))
+		}
+
+		return list
+	case reflect.Struct, reflect.Map:
+		return v
+	}
+
+	return nil
+}
+
+// Returns v with the structs and maps it holds turned back into Go values.
+func export(v any) any {
+	switch v := v.(type) {
+	case reflect.Value:
+		if v.CanInterface() {
+			return v.Interface()
+		}
+
+		return nil
+	case []any:
+		list := make([]any, len(v))
+		for i, el := range v {
+			list[i] = export(el)
+		}
+
+		return list
+	}
+
+	return v
+}
+
+/*
+Returns the kind of the result of a binary operator applied to operands
+of kinds l and r, or false when the operator is not defined on them.
+*/
+func binaryKind(op string, l, r kind) (kind, bool) {
+	switch op {
+	case "&&", "||":
+		return kindBool, accepts(l, kindBool) && accepts(r, kindBool)
+	case "==", "!=":
+		return kindBool, l == kindAny || r == kindAny || l == kindNil || r == kindNil ||
+			l == r || isNumber(l) && isNumber(r)
+	case "<", "<=", ">", ">=":
+		return kindBool, accepts(l, kindInt, kindFloat) && accepts(r, kindInt, kindFloat) ||
+			accepts(l, kindString) && accepts(r, kindString)
+	case "in":
+		if r == kindString {
+			return kindBool, accepts(l, kindString)
+		}
+
+		return kindBool, accepts(r, kindList, kindString)
+	case "+":
+		if l == kindString || r == kindString {
+			return kindString, accepts(l, kindString) && accepts(r, kindString)
+		}
+	}
+
+	if !accepts(l, kindInt, kindFloat) || !accepts(r, kindInt, kindFloat) {
+		return kindAny, false
+	}
+
+	switch {
+	case op == "/":
+		return kindFloat, true
+	case l == kindAny || r == kindAny:
+		return kindAny, true
+	case l == kindInt && r == kindInt:
+		return kindInt, true
+	}
+
+	return kindFloat, true
+}
+
+// Returns the result of a binary operator other than && and ||.
+func binary(pos int, op string, l, r any) (any, error) {
+	if _, isDefined := binaryKind(op, kindOfValue(l), kindOfValue(r)); !isDefined {
+		return nil, newError(pos, ErrType, "operator %s is not defined on %v and %v", op, kindOfValue(l), kindOfValue(r))
+	}
+
+	switch op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "in":
+		if s, isString := r.(string); isString {
+			return strings.Contains(s, l.(string)), nil
+		}
+
+		for _, el := range r.([]any) {
+			if equal(l, el) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case "<", "<=", ">", ">=":
+		cmp, isOrdered := compare(l, r)
+
+		switch {
+		case !isOrdered:
+			return false, nil
+		case op == "<":
+			return cmp < 0, nil
+		case op == "<=":
+			return cmp <= 0, nil
+		case op == ">":
+			return cmp > 0, nil
+		}
+
+		return cmp >= 0, nil
+	}
+
+	if s, isString := l.(string); isString {
+		return s + r.(string), nil
+	}
+
+	x, isIntX := l.(int64)
+	y, isIntY := r.(int64)
+
+	if isIntX && isIntY && op != "/" {
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		}
+
+		if y == 0 {
+			return nil, newError(pos, ErrRuntime, "division by zero")
+		}
+
+		return x % y, nil
+	}
+
+	a, b := toFloat(l), toFloat(r)
+
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	}
+
+	if b == 0 {
+		return nil, newError(pos, ErrRuntime, "division by zero")
+	}
+
+	if op == "/" {
+		return a / b, nil
+	}
+
+	return math.Mod(a, b), nil
+}
+
+func toFloat(v any) float64 {
+	if i, isInt := v.(int64); isInt {
+		return float64(i)
+	}
+
+	return v.(float64)
+}
+
+func equal(l, r any) bool {
+	switch l := l.(type) {
+	case nil:
+		return r == nil
+	case int64, float64:
+		if !isNumber(kindOfValue(r)) {
+			return false
+		}
+
+		cmp, isOrdered := compare(l, r)
+
+		return isOrdered && cmp == 0
+	case []any:
+		list, isList := r.([]any)
+		if !isList || len(list) != len(l) {
+			return false
+		}
+
+		for i := range l {
+			if !equal(l[i], list[i]) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Value:
+		other, isValue := r.(reflect.Value)
+		return isValue && l.Type() == other.Type() && l.Comparable() && l.Equal(other)
+	}
+
+	return l == r
+}
+
+/*
+Returns the order of two numbers or two strings. NaN is unordered:
+false is returned when either number is NaN, so that every comparison
+with it but != is false.
+*/
+func compare(l, r any) (int, bool) {
+	if s, isString := l.(string); isString {
+		return strings.Compare(s, r.(string)), true
+	}
+
+	x, isIntX := l.(int64)
+	y, isIntY := r.(int64)
+
+	if isIntX && isIntY {
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+
+		return 0, true
+	}
+
+	a, b := toFloat(l), toFloat(r)
+
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return 0, false
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+
+	return 0, true
+}
+
+type fieldKey struct {
+	t    reflect.Type
+	name string
+}
+
+var fieldCache sync.Map
+
+/*
+Looks a field up by its Go name, then by its json tag name and finally
+by its Go name ignoring case, so that rules can be written against the
+names used in the stored documents.
+*/
+func lookupField(t reflect.Type, name string) (reflect.StructField, bool) {
+	key := fieldKey{t, name}
+	if cached, isCached := fieldCache.Load(key); isCached {
+		return cached.(reflect.StructField), true
+	}
+
+	// Only names spelled as in the type are cached, so that untrusted
+	// expressions cannot grow the cache with misses or case variants.
+	sf, isFound := findField(t, name)
+	if tag, _, _ := strings.Cut(sf.Tag.Get("json"), ","); isFound && (sf.Name == name || tag == name) {
+		fieldCache.Store(key, sf)
+	}
+
+	return sf, isFound
+}
+
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if sf, isFound := t.FieldByName(name); isFound && sf.IsExported() {
+		return sf, true
+	}
+
+	fields := reflect.VisibleFields(t)
+
+	for _, sf := range fields {
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if sf.IsExported() && tag == name {
+			return sf, true
+		}
+	}
+
+	for _, sf := range fields {
+		if sf.IsExported() && !sf.Anonymous && strings.EqualFold(sf.Name, name) {
+			return sf, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+/*
+Returns the field or map entry called name of v. Selecting from nil
+gives nil, and so does a missing map key.
+*/
+func member(pos int, v any, name string) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	rv, isObject := v.(reflect.Value)
+	if !isObject {
+		return nil, newError(pos, ErrType, "cannot select %s from %v", name, kindOfValue(v))
+	}
+
+	if rv.Kind() == reflect.Map {
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, newError(pos, ErrType, "cannot select %s from %v", name, rv.Type())
+		}
+
+		return normalize(rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))), nil
+	}
+
+	sf, isFound := lookupField(rv.Type(), name)
+	if !isFound {
+		return nil, newError(pos, ErrUnknownName, "%v has no field %s", rv.Type(), name)
+	}
+
+	field, err := rv.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return nil, nil
+	}
+
+	return normalize(field), nil
+}
+
+// Returns the element of a list at an index, or the entry of a map at a key.
+func index(pos int, v, i any) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		n, isInt := i.(int64)
+		if !isInt {
+			return nil, newError(pos, ErrType, "list index must be int, not %v", kindOfValue(i))
+		}
+
+		if n < 0 || n >= int64(len(v)) {
+			return nil, newError(pos, ErrRuntime, "index %d out of range [0, %d)", n, len(v))
+		}
+
+		return v[n], nil
+	case reflect.Value:
+		if key, isString := i.(string); isString && v.Kind() == reflect.Map {
+			return member(pos, v, key)
+		}
+	}
+
+	return nil, newError(pos, ErrType, "cannot index %v with %v", kindOfValue(v), kindOfValue(i))
+}
+
+/*
+Returns v converted to the type of V: numbers convert between each
+other, and other values have to be assignable.
+*/
+func convertTo[V any](v any) (V, bool) {
+	var zero V
+
+	v = export(v)
+	if v == nil {
+		return zero, true
+	}
+
+	if result, isV := v.(V); isV {
+		return result, true
+	}
+
+	rv, vt := reflect.ValueOf(v), reflect.TypeOf(&zero).Elem()
+
+	if isNumber(kindOfValue(v)) && vt.Kind() != reflect.Pointer && isNumber(kindOfType(vt)) {
+		if !fits(v, vt) {
+			return zero, false
+		}
+
+		return rv.Convert(vt).Interface().(V), true
+	}
+
+	if rv.Kind() == vt.Kind() && rv.CanConvert(vt) {
+		return rv.Convert(vt).Interface().(V), true
+	}
+
+	return zero, false
+}
+
+/*
+Reports whether the number v, an int64 or a float64, is in the range of
+the numeric type t. Floats are truncated toward zero before being
+compared with the range of an integer type, and infinities only fit
+into float types.
+*/
+func fits(v any, t reflect.Type) bool {
+	limit := reflect.Zero(t)
+
+	switch v := v.(type) {
+	case int64:
+		switch t.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return v >= 0 && !limit.OverflowUint(uint64(v))
+		}
+
+		return !limit.OverflowInt(v)
+	case float64:
+		if t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+			return math.IsInf(v, 0) || !limit.OverflowFloat(v)
+		}
+
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+
+		v = math.Trunc(v)
+
+		switch t.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			// 2^64 is exact as a float64, unlike the largest uint64.
+			return v >= 0 && v < math.Ldexp(1, 64) && !limit.OverflowUint(uint64(v))
+		}
+
+		return v >= math.Ldexp(-1, 63) && v < math.Ldexp(1, 63) && !limit.OverflowInt(int64(v))
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package gofunc
+
+import "fmt"
+
+/*
+Returns the elements of a collection in the default fmt format,
+such as "[1 2 3]". Implements fmt.Stringer.
+*/
+func (c *collection[T]) String() string {
+	return fmt.Sprint(c.data)
+}
+
+/*
+Returns a Go expression building the collection,
+such as "gofunc.New([]int{1, 2, 3})". Implements fmt.GoStringer.
+*/
+func (c *collection[T]) GoString() string {
+	if c.data == nil {
+		return fmt.Sprintf("gofunc.New(%T{})", c.data)
+	}
+
+	return fmt.Sprintf("gofunc.New(%#v)", c.data)
+}
+
+/*
+Formats a collection as fmt formats a slice of its elements, so verbs,
+flags, width and precision apply to every element: "%x" writes them
+in hexadecimal and "%+v" adds field names to structs. "%#v" writes
+the result of GoString and "%s" is the same as "%v".
+Implements fmt.Formatter.
+*/
+func (c *collection[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = fmt.Fprint(f, c.GoString())
+		return
+	}
+
+	if verb == 's' {
+		verb = 'v'
+	}
+
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), c.data)
+}
@@ -0,0 +1,90 @@
+package gofunc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fmtUser struct {
+	Id   int
+	Name string
+}
+
+func TestString(t *testing.T) {
+	require.Equal(t, "[1 2 3]", New([]int{1, 2, 3}).String())
+	require.Equal(t, "[]", New([]int{}).String())
+	require.Equal(t, "[a b]", fmt.Sprint(New([]string{"a", "b"})))
+}
+
+func TestGoString(t *testing.T) {
+	require.Equal(t, "gofunc.New([]int{1, 2, 3})", New([]int{1, 2, 3}).GoString())
+	require.Equal(t, `gofunc.New([]string{"a"})`, New([]string{"a"}).GoString())
+	require.Equal(t, "gofunc.New([]int{})", New([]int{}).GoString())
+	require.Equal(t, "gofunc.New([]int{})", (&collection[int]{}).GoString())
+	require.Equal(t, `gofunc.New([]gofunc.fmtUser{gofunc.fmtUser{Id:1, Name:"Kate"}})`, fmt.Sprintf("%#v", New([]fmtUser{{1, "Kate"}})))
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		input    any
+		expected string
+	}{
+		{
+			name:     "test1",
+			format:   "%v",
+			input:    New([]int{1, 2, 3}),
+			expected: "[1 2 3]",
+		},
+		{
+			name:     "test2",
+			format:   "%+v",
+			input:    New([]fmtUser{{1, "Kate"}}),
+			expected: "[{Id:1 Name:Kate}]",
+		},
+		{
+			name:     "test3",
+			format:   "%x",
+			input:    New([]int{10, 255}),
+			expected: "[a ff]",
+		},
+		{
+			name:     "test4",
+			format:   "%03d",
+			input:    New([]int{7, 42}),
+			expected: "[007 042]",
+		},
+		{
+			name:     "test5",
+			format:   "%.1f",
+			input:    New([]float64{1.25, 3}),
+			expected: "[1.2 3.0]",
+		},
+		{
+			name:     "test6",
+			format:   "%s",
+			input:    New([]int{1, 2}),
+			expected: "[1 2]",
+		},
+		{
+			name:     "test7",
+			format:   "%q",
+			input:    New([]string{"a", "b"}),
+			expected: `["a" "b"]`,
+		},
+		{
+			name:     "test8",
+			format:   "%#v",
+			input:    New([]int{1}),
+			expected: "gofunc.New([]int{1})",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		require.Equal(t, test.expected, fmt.Sprintf(test.format, test.input))
+	}
+}
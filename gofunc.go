@@ -1,5 +1,7 @@
 package gofunc
 
+import "strings"
+
 type collection[T comparable] struct {
 	data []T
 }
@@ -178,6 +180,91 @@ func (c *collection[T]) Distinct() *collection[T] {
 	return newcollection
 }
 
+/*
+Returns a collection consisting of the elements that have distinct
+keys, keeping the first element met for each key.
+*/
+func DistinctBy[T, K comparable](c *collection[T], key func(el T) K) *collection[T] {
+	if key == nil {
+		return New(c.data)
+	}
+
+	newcollection := New[T](make([]T, 0, len(c.data)))
+	unique := make(map[K]bool)
+
+	for _, value := range c.data {
+		k := key(value)
+
+		if _, isExists := unique[k]; !isExists {
+			unique[k] = true
+			newcollection.data = append(newcollection.data, value)
+		}
+	}
+
+	return newcollection
+}
+
+/*
+Returns a collection consisting of the elements that have distinct
+keys, keeping the last element met for each key. The elements stay
+in the order of their last occurrence.
+*/
+func DistinctByKeepLast[T, K comparable](c *collection[T], key func(el T) K) *collection[T] {
+	if key == nil {
+		return New(c.data)
+	}
+
+	newcollection := New[T](make([]T, 0, len(c.data)))
+	unique := make(map[K]bool)
+
+	for i := len(c.data) - 1; i >= 0; i-- {
+		k := key(c.data[i])
+
+		if _, isExists := unique[k]; !isExists {
+			unique[k] = true
+			newcollection.data = append(newcollection.data, c.data[i])
+		}
+	}
+
+	return newcollection.Reverse()
+}
+
+/*
+Returns a collection in which every run of equal
+neighbouring elements is replaced by a single element.
+*/
+func (c *collection[T]) DistinctConsecutive() *collection[T] {
+	newcollection := New[T](make([]T, 0, len(c.data)))
+
+	for i, value := range c.data {
+		if i == 0 || value != c.data[i-1] {
+			newcollection.data = append(newcollection.data, value)
+		}
+	}
+
+	return newcollection
+}
+
+/*
+Returns the elements that appear in this collection more than once,
+mapped to the number of their occurrences.
+*/
+func (c *collection[T]) Duplicates() map[T]int {
+	counts := make(map[T]int)
+
+	for _, value := range c.data {
+		counts[value]++
+	}
+
+	for value, count := range counts {
+		if count < 2 {
+			delete(counts, value)
+		}
+	}
+
+	return counts
+}
+
 /*
 Returns a collection consisting of the elements of this collection,
 truncated to be no longer than n in length.
@@ -243,19 +330,22 @@ passed to targets with the element passed to replacement.
 */
 func (c *collection[T]) Replace(targets []T, replacement T) *collection[T] {
 	newcollection := New[T](c.data)
+	remaining := make(map[T]int, len(targets))
 
-Exit:
-	for i := 0; i < len(newcollection.data); i++ {
-		for j := 0; j < len(targets); j++ {
-			if newcollection.data[i] == targets[j] {
-				newcollection.data[i] = replacement
-				targets = append(targets[:j], targets[j+1:]...)
+	for _, target := range targets {
+		remaining[target]++
+	}
+
+	for i := 0; i < len(newcollection.data) && len(remaining) > 0; i++ {
+		value := newcollection.data[i]
 
-				if len(targets) == 0 {
-					continue Exit
-				}
+		if count, isExists := remaining[value]; isExists {
+			newcollection.data[i] = replacement
 
-				break
+			if count == 1 {
+				delete(remaining, value)
+			} else {
+				remaining[value] = count - 1
 			}
 		}
 	}
@@ -269,14 +359,68 @@ objects with the element passed to replace.
 */
 func (c *collection[T]) ReplaceAll(targets []T, replacement T) *collection[T] {
 	newcollection := New[T](c.data)
+	unique := make(map[T]bool, len(targets))
+
+	for _, target := range targets {
+		unique[target] = true
+	}
 
 	for i := 0; i < len(newcollection.data); i++ {
-		for j := 0; j < len(targets); j++ {
-			if newcollection.data[i] == targets[j] {
-				newcollection.data[i] = replacement
+		if unique[newcollection.data[i]] {
+			newcollection.data[i] = replacement
+		}
+	}
 
-				break
-			}
+	return newcollection
+}
+
+/*
+Replaces every element of the collection found among the keys
+of replacements with the corresponding value.
+*/
+func (c *collection[T]) ReplaceMap(replacements map[T]T) *collection[T] {
+	newcollection := New[T](c.data)
+
+	for i := 0; i < len(newcollection.data); i++ {
+		if replacement, isExists := replacements[newcollection.data[i]]; isExists {
+			newcollection.data[i] = replacement
+		}
+	}
+
+	return newcollection
+}
+
+/*
+Replaces every element of the collection that matches the given
+condition with the result of applying the replace function to it.
+*/
+func (c *collection[T]) ReplaceFunc(filter func(el T) bool, replace func(el T) T) *collection[T] {
+	if filter == nil || replace == nil {
+		return New(c.data)
+	}
+
+	newcollection := New[T](c.data)
+
+	for i := 0; i < len(newcollection.data); i++ {
+		if filter(newcollection.data[i]) {
+			newcollection.data[i] = replace(newcollection.data[i])
+		}
+	}
+
+	return newcollection
+}
+
+/*
+Replaces the first n occurrences of target with replacement.
+If n < 0, there is no limit on the number of replacements.
+*/
+func (c *collection[T]) ReplaceN(target, replacement T, n int) *collection[T] {
+	newcollection := New[T](c.data)
+
+	for i := 0; i < len(newcollection.data) && n != 0; i++ {
+		if newcollection.data[i] == target {
+			newcollection.data[i] = replacement
+			n--
 		}
 	}
 
@@ -347,15 +491,15 @@ func (c *collection[T]) ToSlice() []T {
 Converts a collection to a string.
 */
 func (c *collection[T]) ToString(convert func(el T) string) string {
-	var resultStr string
+	var resultStr strings.Builder
 
 	if convert == nil {
-		return resultStr
+		return resultStr.String()
 	}
 
 	for _, value := range c.data {
-		resultStr += convert(value)
+		resultStr.WriteString(convert(value))
 	}
 
-	return resultStr
+	return resultStr.String()
 }
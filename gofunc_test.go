@@ -319,6 +319,129 @@ func TestDistinct(t *testing.T) {
 	}
 }
 
+type distinctUser struct {
+	id      int
+	version int
+}
+
+func TestDistinctBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[distinctUser]
+		key      func(distinctUser) int
+		expected *collection[distinctUser]
+	}{
+		{
+			name:     "test1",
+			input:    New([]distinctUser{{1, 1}, {2, 1}, {1, 2}, {3, 1}, {2, 2}}),
+			key:      func(el distinctUser) int { return el.id },
+			expected: New([]distinctUser{{1, 1}, {2, 1}, {3, 1}}),
+		},
+		{
+			name:     "test2",
+			input:    New([]distinctUser{}),
+			key:      func(el distinctUser) int { return el.id },
+			expected: New([]distinctUser{}),
+		},
+		{
+			name:     "test3",
+			input:    New([]distinctUser{{1, 1}, {1, 2}}),
+			key:      nil,
+			expected: New([]distinctUser{{1, 1}, {1, 2}}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := DistinctBy(test.input, test.key)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestDistinctByKeepLast(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[distinctUser]
+		key      func(distinctUser) int
+		expected *collection[distinctUser]
+	}{
+		{
+			name:     "test1",
+			input:    New([]distinctUser{{1, 1}, {2, 1}, {1, 2}, {3, 1}, {2, 2}}),
+			key:      func(el distinctUser) int { return el.id },
+			expected: New([]distinctUser{{1, 2}, {3, 1}, {2, 2}}),
+		},
+		{
+			name:     "test2",
+			input:    New([]distinctUser{}),
+			key:      func(el distinctUser) int { return el.id },
+			expected: New([]distinctUser{}),
+		},
+		{
+			name:     "test3",
+			input:    New([]distinctUser{{1, 1}, {1, 2}}),
+			key:      nil,
+			expected: New([]distinctUser{{1, 1}, {1, 2}}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := DistinctByKeepLast(test.input, test.key)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestDistinctConsecutive(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 1, 2, 2, 2, 1, 3, 3}),
+			expected: New([]int{1, 2, 1, 3}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{}),
+			expected: New([]int{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.DistinctConsecutive()
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		expected map[int]int
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 1, 3, 1, 2, 4}),
+			expected: map[int]int{1: 3, 2: 2},
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			expected: map[int]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.Duplicates()
+		require.Equal(t, test.expected, result)
+	}
+}
+
 func TestLimit(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -534,6 +657,120 @@ func TestReplaceAll(t *testing.T) {
 	}
 }
 
+func TestReplaceTargetsNotMutated(t *testing.T) {
+	targets := []int{1, 2, 1}
+	collection := New([]int{1, 2, 3, 1, 2, 1}).Replace(targets, 0)
+
+	require.Equal(t, New([]int{0, 0, 3, 0, 2, 1}), collection)
+	require.Equal(t, []int{1, 2, 1}, targets)
+}
+
+func TestReplaceMap(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        *collection[int]
+		replacements map[int]int
+		expected     *collection[int]
+	}{
+		{
+			name:         "test1",
+			input:        New([]int{1, 2, 3, 4, 5, 1, 2, 3}),
+			replacements: map[int]int{1: 10, 3: 30},
+			expected:     New([]int{10, 2, 30, 4, 5, 10, 2, 30}),
+		},
+		{
+			name:         "test2",
+			input:        New([]int{1, 2, 3}),
+			replacements: map[int]int{1: 2, 2: 1},
+			expected:     New([]int{2, 1, 3}),
+		},
+		{
+			name:         "test3",
+			input:        New([]int{1, 2, 3}),
+			replacements: nil,
+			expected:     New([]int{1, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.ReplaceMap(test.replacements)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestReplaceFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		filter   func(int) bool
+		replace  func(int) int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			filter:   func(el int) bool { return el%2 == 0 },
+			replace:  func(el int) int { return -el },
+			expected: New([]int{1, -2, 3, -4, 5}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{}),
+			filter:   func(el int) bool { return el%2 == 0 },
+			replace:  func(el int) int { return -el },
+			expected: New([]int{}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3}),
+			filter:   nil,
+			replace:  func(el int) int { return -el },
+			expected: New([]int{1, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.ReplaceFunc(test.filter, test.replace)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestReplaceN(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		n        int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 1, 3, 1}),
+			n:        2,
+			expected: New([]int{0, 2, 0, 3, 1}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 1, 3, 1}),
+			n:        -1,
+			expected: New([]int{0, 2, 0, 3, 0}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 1, 3, 1}),
+			n:        0,
+			expected: New([]int{1, 2, 1, 3, 1}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.ReplaceN(1, 0, test.n)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
 func TestMax(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,215 @@
+package gofunc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// LineError records a failure to decode one line of input.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("gofunc: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Reads a JSON array from r and returns a collection of its elements.
+The elements are decoded one at a time, so the raw input is never
+held in memory as a whole. Anything but white space after the array
+is an error. Reading stops at the first element that fails to decode,
+which is reported as a *LineError with the line the element starts on:
+unlike the lines of NDJSON, the elements of an array are not delimited
+independently of their content, so nothing after a malformed element
+can be trusted.
+*/
+func FromJSONArray[T comparable](r io.Reader) (*collection[T], error) {
+	lines := &lineReader{r: r}
+	decoder := json.NewDecoder(lines)
+	newcollection := New(make([]T, 0))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("gofunc: expected JSON array, got %v", token)
+	}
+
+	for i := 0; decoder.More(); i++ {
+		var value T
+
+		start := valueOffset(decoder)
+
+		if err := decoder.Decode(&value); err != nil {
+			return nil, &LineError{Line: lines.line(start), Err: fmt.Errorf("element %d: %w", i, err)}
+		}
+
+		newcollection.data = append(newcollection.data, value)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, &LineError{Line: lines.line(valueOffset(decoder)), Err: err}
+	}
+
+	end := valueOffset(decoder)
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, &LineError{Line: lines.line(end), Err: errors.New("unexpected data after JSON array")}
+	}
+
+	return newcollection, nil
+}
+
+/*
+lineReader keeps the offsets of the newlines read through it, so that
+offsets reported by a json.Decoder can be turned into line numbers.
+*/
+type lineReader struct {
+	r        io.Reader
+	read     int64
+	newlines []int64
+	passed   int
+}
+
+func (lr *lineReader) Read(p []byte) (int, error) {
+	n, err := lr.r.Read(p)
+
+	for i, b := range p[:n] {
+		if b == '\n' {
+			lr.newlines = append(lr.newlines, lr.read+int64(i))
+		}
+	}
+
+	lr.read += int64(n)
+
+	return n, err
+}
+
+/*
+Returns the line of the byte at offset. The newlines before it are
+forgotten, so offsets must be asked for in increasing order.
+*/
+func (lr *lineReader) line(offset int64) int {
+	i := sort.Search(len(lr.newlines), func(i int) bool { return lr.newlines[i] >= offset })
+
+	lr.passed += i
+	lr.newlines = lr.newlines[i:]
+
+	return lr.passed + 1
+}
+
+// Returns the offset of the next value of the decoder, past white space and commas.
+func valueOffset(decoder *json.Decoder) int64 {
+	offset := decoder.InputOffset()
+	buffered := decoder.Buffered()
+	b := make([]byte, 1)
+
+	for {
+		if n, _ := buffered.Read(b); n == 0 || !strings.ContainsRune(" \t\r\n,", rune(b[0])) {
+			return offset
+		}
+
+		offset++
+	}
+}
+
+/*
+Reads newline delimited JSON from r and returns a collection of the
+values found on its lines. Blank lines are skipped. Lines that fail
+to decode are skipped as well and reported as *LineError values
+joined into the returned error, next to the collection of the
+values that were decoded.
+*/
+func FromNDJSON[T comparable](r io.Reader) (*collection[T], error) {
+	reader := bufio.NewReader(r)
+	newcollection := New(make([]T, 0))
+	var errs []error
+
+	for line := 1; ; line++ {
+		data, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, readErr
+		}
+
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var value T
+
+			if err := json.Unmarshal(data, &value); err != nil {
+				errs = append(errs, &LineError{Line: line, Err: err})
+			} else {
+				newcollection.data = append(newcollection.data, value)
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	return newcollection, errors.Join(errs...)
+}
+
+/*
+Writes the elements of this collection to w as a JSON array,
+encoding them one at a time.
+*/
+func (c *collection[T]) WriteJSONArray(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	if err := writer.WriteByte('['); err != nil {
+		return err
+	}
+
+	for i, value := range c.data {
+		if i > 0 {
+			if err := writer.WriteByte(','); err != nil {
+				return err
+			}
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("gofunc: element %d: %w", i, err)
+		}
+
+		if _, err := writer.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.WriteByte(']'); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+/*
+Writes the elements of this collection to w
+as newline delimited JSON, one element per line.
+*/
+func (c *collection[T]) WriteNDJSON(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+
+	for i, value := range c.data {
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Errorf("gofunc: element %d: %w", i, err)
+		}
+	}
+
+	return writer.Flush()
+}
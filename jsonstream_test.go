@@ -0,0 +1,164 @@
+package gofunc
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type streamUser struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFromJSONArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *collection[streamUser]
+		isError  bool
+	}{
+		{
+			name:     "test1",
+			input:    `[{"id":1,"name":"Kate"}, {"id":2,"name":"John"}]`,
+			expected: New([]streamUser{{1, "Kate"}, {2, "John"}}),
+		},
+		{
+			name:     "test2",
+			input:    `[]`,
+			expected: New([]streamUser{}),
+		},
+		{
+			name:    "test3",
+			input:   `{"id":1}`,
+			isError: true,
+		},
+		{
+			name:    "test4",
+			input:   `[{"id":1}, {"id":"x"}]`,
+			isError: true,
+		},
+		{
+			name:    "test5",
+			input:   `[{"id":1}`,
+			isError: true,
+		},
+		{
+			name:    "test6",
+			input:   `[{"id":1}] [{"id":2}]`,
+			isError: true,
+		},
+		{
+			name:    "test7",
+			input:   `[{"id":1}] garbage`,
+			isError: true,
+		},
+		{
+			name:     "test8",
+			input:    "[{\"id\":1}]\n\t ",
+			expected: New([]streamUser{{1, ""}}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection, err := FromJSONArray[streamUser](strings.NewReader(test.input))
+
+		if test.isError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestFromJSONArrayLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "test1",
+			input:    "[\n  {\"id\":1},\n  {\"id\":2},\n\n  {\"id\":\"x\"}\n]",
+			expected: "gofunc: line 5: element 2: json: cannot unmarshal string into Go struct field streamUser.id of type int",
+		},
+		{
+			name:     "test2",
+			input:    "[{\"id\":1},\n{\"id\":2}]\n\n[3]",
+			expected: "gofunc: line 4: unexpected data after JSON array",
+		},
+		{
+			name:     "test3",
+			input:    "[\n{\"id\":1},\n{\"id\" 2}]",
+			expected: "gofunc: line 3: element 1: invalid character '2' after object key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		_, err := FromJSONArray[streamUser](strings.NewReader(test.input))
+		require.EqualError(t, err, test.expected)
+
+		var lineErr *LineError
+		require.True(t, errors.As(err, &lineErr))
+	}
+}
+
+func TestFromNDJSON(t *testing.T) {
+	input := "{\"id\":1,\"name\":\"Kate\"}\n\n{\"id\":\"x\"}\n{\"id\":2,\"name\":\"John\"}"
+
+	collection, err := FromNDJSON[streamUser](strings.NewReader(input))
+	require.Equal(t, New([]streamUser{{1, "Kate"}, {2, "John"}}), collection)
+
+	var lineErr *LineError
+	require.True(t, errors.As(err, &lineErr))
+	require.Equal(t, 3, lineErr.Line)
+
+	collection, err = FromNDJSON[streamUser](strings.NewReader(""))
+	require.NoError(t, err)
+	require.Equal(t, New([]streamUser{}), collection)
+}
+
+func TestWriteJSONArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[streamUser]
+		expected string
+	}{
+		{
+			name:     "test1",
+			input:    New([]streamUser{{1, "Kate"}, {2, "John"}}),
+			expected: `[{"id":1,"name":"Kate"},{"id":2,"name":"John"}]`,
+		},
+		{
+			name:     "test2",
+			input:    New([]streamUser{}),
+			expected: `[]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		var buf bytes.Buffer
+		require.NoError(t, test.input.WriteJSONArray(&buf))
+		require.Equal(t, test.expected, buf.String())
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	input := New([]streamUser{{1, "Kate"}, {2, "John"}})
+	require.NoError(t, input.WriteNDJSON(&buf))
+	require.Equal(t, "{\"id\":1,\"name\":\"Kate\"}\n{\"id\":2,\"name\":\"John\"}\n", buf.String())
+
+	output, err := FromNDJSON[streamUser](&buf)
+	require.NoError(t, err)
+	require.Equal(t, input, output)
+}
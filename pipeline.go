@@ -0,0 +1,257 @@
+package gofunc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var ErrUnknownFunc = errors.New("unknown function")
+
+/*
+Step is one operation of a pipeline. Op is one of filter, map, sort,
+distinct, limit, skip and reverse. Func names the registered function
+the step uses: a filter for filter, a mapping for map, an ordering for
+sort and, optionally, a key for distinct. N is the count of limit and
+skip, and Desc reverses the order of sort.
+*/
+type Step struct {
+	Op   string `json:"op" yaml:"op"`
+	Func string `json:"func,omitempty" yaml:"func,omitempty"`
+	N    *int   `json:"n,omitempty" yaml:"n,omitempty"`
+	Desc bool   `json:"desc,omitempty" yaml:"desc,omitempty"`
+}
+
+/*
+Pipeline describes a sequence of steps applied to a collection,
+so that transformations can be kept in configuration files. It
+decodes from JSON and YAML documents such as
+
+	name: adults
+	steps:
+	  - op: filter
+	    func: isAdult
+	  - op: sort
+	    func: byAge
+	    desc: true
+	  - op: limit
+	    n: 10
+*/
+type Pipeline struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Steps []Step `json:"steps" yaml:"steps"`
+}
+
+// StepError records why one step of a pipeline is invalid.
+type StepError struct {
+	Step int
+	Op   string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("gofunc: step %d (%s): %v", e.Step, e.Op, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Registry exposes Go functions under names, so that the steps of
+a pipeline can refer to them. Filters, mappings, orderings and keys
+have separate namespaces. A Registry is safe for concurrent use.
+*/
+type Registry[T comparable] struct {
+	mu       sync.RWMutex
+	filters  map[string]func(el T) bool
+	mappings map[string]func(el T) T
+	orders   map[string]func(a, b T) bool
+	keys     map[string]func(c *collection[T]) *collection[T]
+}
+
+// Returns an empty registry.
+func NewRegistry[T comparable]() *Registry[T] {
+	return &Registry[T]{
+		filters:  make(map[string]func(el T) bool),
+		mappings: make(map[string]func(el T) T),
+		orders:   make(map[string]func(a, b T) bool),
+		keys:     make(map[string]func(c *collection[T]) *collection[T]),
+	}
+}
+
+// Registers a filter under name, replacing the previous one.
+func (r *Registry[T]) RegisterFilter(name string, filter func(el T) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.filters[name] = filter
+}
+
+// Registers a mapping under name, replacing the previous one.
+func (r *Registry[T]) RegisterMap(name string, predicate func(el T) T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mappings[name] = predicate
+}
+
+/*
+Registers an ordering under name, replacing the previous one.
+less reports whether a sorts before b.
+*/
+func (r *Registry[T]) RegisterSort(name string, less func(a, b T) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.orders[name] = less
+}
+
+/*
+Registers a key under name, replacing the previous one.
+Distinct steps naming it keep the first element of each key.
+*/
+func RegisterKey[T, K comparable](r *Registry[T], name string, key func(el T) K) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[name] = func(c *collection[T]) *collection[T] {
+		return DistinctBy(c, key)
+	}
+}
+
+/*
+Returns a function applying the pipeline to a collection. Every step
+is checked before anything runs: invalid steps are reported as
+*StepError values joined into the returned error, and references to
+unregistered functions wrap ErrUnknownFunc. The functions are looked
+up once, so later registrations do not change the result.
+*/
+func (r *Registry[T]) Compile(p Pipeline) (func(c *collection[T]) *collection[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stages := make([]func(c *collection[T]) *collection[T], 0, len(p.Steps))
+	var errs []error
+
+	for i, step := range p.Steps {
+		stage, err := r.compileStep(step)
+		if err != nil {
+			errs = append(errs, &StepError{Step: i + 1, Op: step.Op, Err: err})
+			continue
+		}
+
+		stages = append(stages, stage)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return func(c *collection[T]) *collection[T] {
+		newcollection := New(c.data)
+
+		for _, stage := range stages {
+			newcollection = stage(newcollection)
+		}
+
+		return newcollection
+	}, nil
+}
+
+// Returns the result of applying the pipeline to c, or an error if it is invalid.
+func (r *Registry[T]) Run(p Pipeline, c *collection[T]) (*collection[T], error) {
+	run, err := r.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return run(c), nil
+}
+
+func (r *Registry[T]) compileStep(step Step) (func(c *collection[T]) *collection[T], error) {
+	switch step.Op {
+	case "filter", "map", "sort":
+		if step.Func == "" {
+			return nil, errors.New("func is required")
+		}
+	case "limit", "skip":
+		if step.N == nil {
+			return nil, errors.New("n is required")
+		} else if *step.N < 0 {
+			return nil, fmt.Errorf("n must not be negative, got %d", *step.N)
+		}
+	case "distinct", "reverse":
+	default:
+		return nil, errors.New("unknown op")
+	}
+
+	if step.N != nil && step.Op != "limit" && step.Op != "skip" {
+		return nil, errors.New("n is not allowed")
+	}
+
+	if step.Desc && step.Op != "sort" {
+		return nil, errors.New("desc is not allowed")
+	}
+
+	if step.Func != "" && (step.Op == "limit" || step.Op == "skip" || step.Op == "reverse") {
+		return nil, errors.New("func is not allowed")
+	}
+
+	unknown := func(kind string) error {
+		return fmt.Errorf("%s %q: %w", kind, step.Func, ErrUnknownFunc)
+	}
+
+	switch step.Op {
+	case "filter":
+		filter, isFound := r.filters[step.Func]
+		if !isFound {
+			return nil, unknown("filter")
+		}
+
+		return func(c *collection[T]) *collection[T] { return c.Filter(filter) }, nil
+	case "map":
+		predicate, isFound := r.mappings[step.Func]
+		if !isFound {
+			return nil, unknown("mapping")
+		}
+
+		return func(c *collection[T]) *collection[T] { return c.Map(predicate) }, nil
+	case "sort":
+		less, isFound := r.orders[step.Func]
+		if !isFound {
+			return nil, unknown("ordering")
+		}
+
+		if step.Desc {
+			ascending := less
+			less = func(a, b T) bool { return ascending(b, a) }
+		}
+
+		return func(c *collection[T]) *collection[T] {
+			return c.Sort(func(arr []T) {
+				sort.SliceStable(arr, func(i, j int) bool { return less(arr[i], arr[j]) })
+			})
+		}, nil
+	case "distinct":
+		if step.Func == "" {
+			return func(c *collection[T]) *collection[T] { return c.Distinct() }, nil
+		}
+
+		distinct, isFound := r.keys[step.Func]
+		if !isFound {
+			return nil, unknown("key")
+		}
+
+		return distinct, nil
+	case "limit":
+		n := *step.N
+		return func(c *collection[T]) *collection[T] { return c.Limit(n) }, nil
+	case "skip":
+		n := *step.N
+		return func(c *collection[T]) *collection[T] { return c.Skip(n) }, nil
+	}
+
+	return func(c *collection[T]) *collection[T] { return c.Reverse() }, nil
+}
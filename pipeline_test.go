@@ -0,0 +1,149 @@
+package gofunc
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type pipelineUser struct {
+	Name string
+	Age  int
+}
+
+func pipelineRegistry() *Registry[pipelineUser] {
+	r := NewRegistry[pipelineUser]()
+
+	r.RegisterFilter("isAdult", func(el pipelineUser) bool { return el.Age >= 18 })
+	r.RegisterMap("upperName", func(el pipelineUser) pipelineUser {
+		return pipelineUser{strings.ToUpper(el.Name), el.Age}
+	})
+	r.RegisterSort("byAge", func(a, b pipelineUser) bool { return a.Age < b.Age })
+	RegisterKey(r, "age", func(el pipelineUser) int { return el.Age })
+
+	return r
+}
+
+func TestPipelineRun(t *testing.T) {
+	users := New([]pipelineUser{{"Kate", 25}, {"John", 17}, {"Kim", 31}, {"Sam", 25}, {"Karl", 45}})
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected []pipelineUser
+	}{
+		{
+			name: "test1",
+			spec: `{"name": "adults", "steps": [
+				{"op": "filter", "func": "isAdult"},
+				{"op": "sort", "func": "byAge", "desc": true},
+				{"op": "limit", "n": 3}
+			]}`,
+			expected: []pipelineUser{{"Karl", 45}, {"Kim", 31}, {"Kate", 25}},
+		},
+		{
+			name: "test2",
+			spec: `{"steps": [
+				{"op": "sort", "func": "byAge"},
+				{"op": "distinct", "func": "age"},
+				{"op": "map", "func": "upperName"},
+				{"op": "skip", "n": 1},
+				{"op": "reverse"}
+			]}`,
+			expected: []pipelineUser{{"KARL", 45}, {"KIM", 31}, {"KATE", 25}},
+		},
+		{
+			name:     "test3",
+			spec:     `{"steps": [{"op": "distinct"}, {"op": "limit", "n": 0}]}`,
+			expected: []pipelineUser{},
+		},
+		{
+			name:     "test4",
+			spec:     `{"steps": []}`,
+			expected: []pipelineUser{{"Kate", 25}, {"John", 17}, {"Kim", 31}, {"Sam", 25}, {"Karl", 45}},
+		},
+	}
+
+	r := pipelineRegistry()
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		var p Pipeline
+		require.NoError(t, json.Unmarshal([]byte(test.spec), &p))
+
+		result, err := r.Run(p, users)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, result.data)
+	}
+}
+
+func TestPipelineYAML(t *testing.T) {
+	spec := `
+name: adults
+steps:
+  - op: filter
+    func: isAdult
+  - op: sort
+    func: byAge
+    desc: true
+  - op: limit
+    n: 2
+`
+
+	var p Pipeline
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &p))
+	require.Equal(t, "adults", p.Name)
+
+	run, err := pipelineRegistry().Compile(p)
+	require.NoError(t, err)
+
+	result := run(New([]pipelineUser{{"Kate", 25}, {"John", 17}, {"Kim", 31}}))
+	require.Equal(t, []pipelineUser{{"Kim", 31}, {"Kate", 25}}, result.data)
+}
+
+func TestPipelineValidate(t *testing.T) {
+	n, negative := 2, -1
+
+	p := Pipeline{Steps: []Step{
+		{Op: "filter", Func: "isAdult"},
+		{Op: "filter", Func: "isAdmin"},
+		{Op: "sort"},
+		{Op: "limit"},
+		{Op: "skip", N: &negative},
+		{Op: "group"},
+		{Op: "reverse", Func: "byAge"},
+		{Op: "map", Func: "byAge"},
+		{Op: "distinct", N: &n},
+		{Op: "limit", N: &n, Desc: true},
+	}}
+
+	_, err := pipelineRegistry().Compile(p)
+	require.True(t, errors.Is(err, ErrUnknownFunc))
+	require.EqualError(t, err, strings.Join([]string{
+		`gofunc: step 2 (filter): filter "isAdmin": unknown function`,
+		`gofunc: step 3 (sort): func is required`,
+		`gofunc: step 4 (limit): n is required`,
+		`gofunc: step 5 (skip): n must not be negative, got -1`,
+		`gofunc: step 6 (group): unknown op`,
+		`gofunc: step 7 (reverse): func is not allowed`,
+		`gofunc: step 8 (map): mapping "byAge": unknown function`,
+		`gofunc: step 9 (distinct): n is not allowed`,
+		`gofunc: step 10 (limit): desc is not allowed`,
+	}, "\n"))
+
+	var stepErr *StepError
+	require.True(t, errors.As(err, &stepErr))
+	require.Equal(t, 2, stepErr.Step)
+
+	r := pipelineRegistry()
+	run, err := r.Compile(Pipeline{Steps: []Step{{Op: "filter", Func: "isAdult"}}})
+	require.NoError(t, err)
+
+	r.RegisterFilter("isAdult", func(el pipelineUser) bool { return false })
+	require.Equal(t, []pipelineUser{{"Kate", 25}}, run(New([]pipelineUser{{"Kate", 25}})).data)
+}
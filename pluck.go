@@ -0,0 +1,163 @@
+package gofunc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type fieldPathKey struct {
+	t    reflect.Type
+	path string
+}
+
+// fieldPaths caches the indexes resolved for a field path on a type.
+var fieldPaths sync.Map
+
+/*
+Returns a collection of the values of the field found by path in every
+element. The path is a field name, or a dotted path to a nested field
+such as "Address.City"; every part of it matches either a field name
+or its `json` tag name, including fields promoted from embedded
+structs. Nil pointers along the path give zero values.
+An error is returned if the field doesn't exist, is unexported
+or can't be assigned to V.
+*/
+func Pluck[T, V comparable](c *collection[T], path string) (*collection[V], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	index, fieldType, err := resolveFieldPath(t, path)
+	if err != nil {
+		return nil, err
+	}
+
+	vType := reflect.TypeOf((*V)(nil)).Elem()
+	if !fieldType.AssignableTo(vType) {
+		return nil, fmt.Errorf("gofunc: field %q of %s is %s, not %s", path, t, fieldType, vType)
+	}
+
+	newcollection := New(make([]V, len(c.data)))
+
+	for i, value := range c.data {
+		field, ok := fieldByPath(reflect.ValueOf(value), index)
+		if ok {
+			reflect.ValueOf(&newcollection.data[i]).Elem().Set(field)
+		}
+	}
+
+	return newcollection, nil
+}
+
+/*
+Returns a slice of maps holding, for every element, the values of the
+fields found by paths, keyed by the paths as given. Paths are resolved
+as in Pluck; nil pointers along a path give nil values.
+*/
+func SelectFields[T comparable](c *collection[T], paths ...string) ([]map[string]any, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	indexes := make([][]int, len(paths))
+
+	for i, path := range paths {
+		index, _, err := resolveFieldPath(t, path)
+		if err != nil {
+			return nil, err
+		}
+
+		indexes[i] = index
+	}
+
+	selected := make([]map[string]any, len(c.data))
+
+	for i, value := range c.data {
+		row := make(map[string]any, len(paths))
+
+		for j, path := range paths {
+			if field, ok := fieldByPath(reflect.ValueOf(value), indexes[j]); ok {
+				row[path] = field.Interface()
+			} else {
+				row[path] = nil
+			}
+		}
+
+		selected[i] = row
+	}
+
+	return selected, nil
+}
+
+func resolveFieldPath(t reflect.Type, path string) ([]int, reflect.Type, error) {
+	type resolved struct {
+		index     []int
+		fieldType reflect.Type
+	}
+
+	key := fieldPathKey{t, path}
+
+	if cached, isExists := fieldPaths.Load(key); isExists {
+		r := cached.(resolved)
+		return r.index, r.fieldType, nil
+	}
+
+	current := t
+	index := make([]int, 0, strings.Count(path, ".")+1)
+
+	for _, name := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+
+		if current.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("gofunc: field %q of %s: %s is not a struct", path, t, current)
+		}
+
+		field, ok := findField(current, name)
+		if !ok {
+			return nil, nil, fmt.Errorf("gofunc: field %q of %s: %s has no field %q", path, t, current, name)
+		}
+
+		if !field.IsExported() {
+			return nil, nil, fmt.Errorf("gofunc: field %q of %s: field %s.%s is unexported", path, t, current, field.Name)
+		}
+
+		// Fields promoted from embedded structs have a longer index.
+		index = append(index, field.Index...)
+		current = field.Type
+	}
+
+	fieldPaths.Store(key, resolved{index, current})
+
+	return index, current, nil
+}
+
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := t.FieldByName(name); ok {
+		return field, true
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if tag != "" && tag == name && !field.Anonymous {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func fieldByPath(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v, true
+}
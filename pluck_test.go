@@ -0,0 +1,128 @@
+package gofunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pluckAddress struct {
+	City string `json:"city"`
+}
+
+type pluckUser struct {
+	Name    string `json:"name,omitempty"`
+	Age     int
+	Address *pluckAddress
+	secret  string
+}
+
+type pluckBase struct {
+	ID      int
+	Created string `json:"created"`
+}
+
+type pluckItem struct {
+	pluckBase
+	*pluckAddress
+	Title string
+}
+
+func TestPluck(t *testing.T) {
+	users := New([]pluckUser{
+		{Name: "Kate", Age: 25, Address: &pluckAddress{"Paris"}},
+		{Name: "John", Age: 17},
+	})
+
+	names, err := Pluck[pluckUser, string](users, "Name")
+	require.NoError(t, err)
+	require.Equal(t, New([]string{"Kate", "John"}), names)
+
+	names, err = Pluck[pluckUser, string](users, "name")
+	require.NoError(t, err)
+	require.Equal(t, New([]string{"Kate", "John"}), names)
+
+	cities, err := Pluck[pluckUser, string](users, "Address.city")
+	require.NoError(t, err)
+	require.Equal(t, New([]string{"Paris", ""}), cities)
+
+	ages, err := Pluck[pluckUser, any](users, "Age")
+	require.NoError(t, err)
+	require.Equal(t, New([]any{25, 17}), ages)
+
+	pointers := New([]*pluckUser{{Name: "Sam"}, nil})
+	names, err = Pluck[*pluckUser, string](pointers, "Name")
+	require.NoError(t, err)
+	require.Equal(t, New([]string{"Sam", ""}), names)
+
+	items := New([]pluckItem{
+		{pluckBase{1, "2024-01-02"}, &pluckAddress{"Paris"}, "Lamp"},
+		{pluckBase{2, "2024-03-04"}, nil, "Desk"},
+	})
+
+	ids, err := Pluck[pluckItem, int](items, "ID")
+	require.NoError(t, err)
+	require.Equal(t, New([]int{1, 2}), ids)
+
+	created, err := Pluck[pluckItem, string](items, "created")
+	require.NoError(t, err)
+	require.Equal(t, New([]string{"2024-01-02", "2024-03-04"}), created)
+
+	cities, err = Pluck[pluckItem, string](items, "City")
+	require.NoError(t, err)
+	require.Equal(t, New([]string{"Paris", ""}), cities)
+}
+
+func TestPluckErrors(t *testing.T) {
+	users := New([]pluckUser{{Name: "Kate"}})
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "missing",
+			path:     "Email",
+			expected: `has no field "Email"`,
+		},
+		{
+			name:     "unexported",
+			path:     "secret",
+			expected: "is unexported",
+		},
+		{
+			name:     "not a struct",
+			path:     "Name.First",
+			expected: "string is not a struct",
+		},
+		{
+			name:     "wrong type",
+			path:     "Age",
+			expected: "is int, not string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		_, err := Pluck[pluckUser, string](users, test.path)
+		require.ErrorContains(t, err, test.expected)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	users := New([]pluckUser{
+		{Name: "Kate", Age: 25, Address: &pluckAddress{"Paris"}},
+		{Name: "John", Age: 17},
+	})
+
+	rows, err := SelectFields(users, "Name", "Address.City")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{
+		{"Name": "Kate", "Address.City": "Paris"},
+		{"Name": "John", "Address.City": nil},
+	}, rows)
+
+	_, err = SelectFields(users, "Name", "Phone")
+	require.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type field struct {
+	path  string
+	pos   int
+	index [][]int
+	t     reflect.Type
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// Keywords are read as field names here, since a field is expected.
+func (p *parser) parseField() (field, error) {
+	tok, err := p.expectName()
+	if err != nil {
+		return field{}, err
+	}
+
+	f := field{path: tok.text, pos: tok.pos, t: p.t}
+
+	for name := tok; ; {
+		st := indirect(f.t)
+		if st.Kind() != reflect.Struct {
+			return field{}, &Error{Pos: name.pos, Msg: fmt.Sprintf("%s is not a struct", strings.TrimSuffix(f.path, "."+name.text))}
+		}
+
+		sf, isFound := lookupField(st, name.text)
+		if !isFound {
+			return field{}, &Error{Pos: name.pos, Msg: fmt.Sprintf("%v has no field %s", st, name.text)}
+		}
+
+		f.index = append(f.index, sf.Index)
+		f.t = sf.Type
+
+		if p.peek().kind != tokenDot {
+			break
+		}
+
+		p.next()
+
+		if name, err = p.expectName(); err != nil {
+			return field{}, err
+		}
+
+		f.path += "." + name.text
+	}
+
+	f.t = indirect(f.t)
+
+	return f, nil
+}
+
+func (p *parser) expectName() (token, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent && tok.kind != tokenKeyword {
+		return tok, unexpected(tok, "a field name")
+	}
+
+	return tok, nil
+}
+
+/*
+Looks a field up by its Go name, then by its json tag name and finally
+by its Go name ignoring case.
+*/
+func lookupField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if sf, isFound := t.FieldByName(name); isFound && sf.IsExported() {
+		return sf, true
+	}
+
+	fields := reflect.VisibleFields(t)
+
+	for _, sf := range fields {
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if sf.IsExported() && tag == name {
+			return sf, true
+		}
+	}
+
+	for _, sf := range fields {
+		if sf.IsExported() && !sf.Anonymous && strings.EqualFold(sf.Name, name) {
+			return sf, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+/*
+Returns the value of the field in v, or false when a nil pointer
+is met on the way.
+*/
+func (f field) get(v reflect.Value) (reflect.Value, bool) {
+	for _, index := range f.index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		var err error
+		if v, err = v.FieldByIndexErr(index); err != nil {
+			return reflect.Value{}, false
+		}
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		v = v.Elem()
+	}
+
+	return v, true
+}
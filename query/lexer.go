@@ -0,0 +1,157 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenKeyword
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenDot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var keywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "LIKE": true, "IN": true,
+	"ORDER": true, "BY": true, "ASC": true, "DESC": true,
+	"LIMIT": true, "OFFSET": true, "TRUE": true, "FALSE": true,
+}
+
+// Returns whether t is the keyword, written in any case.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenKeyword && strings.ToUpper(t.text) == keyword
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("string '%s'", t.text)
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	tokens := make([]token, 0)
+
+	// Positions are counted in bytes, so they can be used to slice the input.
+	offsets := make([]int, len(runes)+1)
+	for i, offset := 0, 0; i < len(runes); i++ {
+		offsets[i] = offset
+		offset += len(string(runes[i]))
+		offsets[i+1] = offset
+	}
+
+	for i := 0; i < len(runes); {
+		r, pos := runes[i], offsets[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "(", pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")", pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", pos})
+			i++
+		case r == '.':
+			tokens = append(tokens, token{tokenDot, ".", pos})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || r == '<' && runes[i+1] == '>') {
+				op += string(runes[i+1])
+			}
+
+			if op == "!" {
+				return nil, &Error{Pos: pos, Msg: "unexpected \"!\", expected \"!=\""}
+			}
+
+			tokens = append(tokens, token{tokenOperator, op, pos})
+			i += len([]rune(op))
+		case r == '\'' || r == '"':
+			var sb strings.Builder
+
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					// A doubled quote stands for a quote inside the string.
+					if j+1 < len(runes) && runes[j+1] == r {
+						sb.WriteRune(r)
+						j++
+
+						continue
+					}
+
+					break
+				}
+
+				sb.WriteRune(runes[j])
+			}
+
+			if j >= len(runes) && r == '"' {
+				return nil, &Error{Pos: pos, Msg: "unterminated quoted field name"}
+			} else if j >= len(runes) {
+				return nil, &Error{Pos: pos, Msg: "unterminated string"}
+			}
+
+			// Double quotes name a field, as in SQL.
+			if r == '"' {
+				tokens = append(tokens, token{tokenIdent, sb.String(), pos})
+			} else {
+				tokens = append(tokens, token{tokenString, sb.String(), pos})
+			}
+
+			i = j + 1
+		case unicode.IsDigit(r) || r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == '_') {
+				j++
+			}
+
+			tokens = append(tokens, token{tokenNumber, string(runes[i:j]), pos})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+
+			// Keywords keep their spelling, so that they can also name fields.
+			text := string(runes[i:j])
+			if keywords[strings.ToUpper(text)] {
+				tokens = append(tokens, token{tokenKeyword, text, pos})
+			} else {
+				tokens = append(tokens, token{tokenIdent, text, pos})
+			}
+
+			i = j
+		default:
+			return nil, &Error{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	return append(tokens, token{tokenEOF, "", len(input)}), nil
+}
@@ -0,0 +1,712 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Error reports a parse or type error at a byte offset of the query.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("query: position %d: %s", e.Pos+1, e.Msg)
+}
+
+/*
+The subset of collection methods a query is compiled into.
+*gofunc.collection[T] satisfies it.
+*/
+type Collection[T, C any] interface {
+	Filter(filter func(el T) bool) C
+	Sort(sort func(arr []T)) C
+	Skip(n int) C
+	Limit(n int) C
+}
+
+// Query is a compiled query over values of type T.
+type Query[T any] struct {
+	where  func(v reflect.Value) bool
+	orders []ordering
+	offset int
+	limit  int
+}
+
+type ordering struct {
+	field field
+	desc  bool
+}
+
+/*
+Returns the compiled form of a query such as
+
+	Age >= 18 AND Name LIKE 'K%' ORDER BY Age DESC LIMIT 10
+
+All clauses are optional, but must appear in the order WHERE
+condition, ORDER BY, LIMIT, OFFSET; the condition itself is written
+without the WHERE keyword. Conditions compare a field with a literal
+(=, !=, <>, <, <=, >, >=), match strings with [NOT] LIKE, where % stands
+for any run of characters and _ for a single one, test membership with
+[NOT] IN (...) and are combined with AND, OR, NOT and parentheses.
+Fields are struct field names, json tag names or dotted paths to nested
+fields; keywords are case-insensitive. A field named like a keyword is
+written as is where a field is expected, such as Order > 1, or in
+double quotes, such as "Order" IN (1, 2). NaN values only satisfy != and
+<>, and sort before every other number. Errors are returned as *Error.
+*/
+func Compile[T any](query string) (*Query[T], error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if indirect(t).Kind() != reflect.Struct {
+		return nil, &Error{Pos: 0, Msg: fmt.Sprintf("%v is not a struct", t)}
+	}
+
+	p := &parser{tokens: tokens, t: t}
+
+	return parse[T](p)
+}
+
+// Returns the compiled form of the query, and panics if it is invalid.
+func MustCompile[T any](query string) *Query[T] {
+	q, err := Compile[T](query)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+/*
+Returns the result of filtering, sorting, skipping and limiting the
+collection as described by the query.
+*/
+func Apply[T any, C Collection[T, C]](q *Query[T], c C) C {
+	if q.where != nil {
+		c = c.Filter(q.Match)
+	}
+
+	if len(q.orders) > 0 {
+		c = c.Sort(q.sort)
+	}
+
+	if q.offset > 0 {
+		c = c.Skip(q.offset)
+	}
+
+	if q.limit >= 0 {
+		c = c.Limit(q.limit)
+	}
+
+	return c
+}
+
+/*
+Returns the result of compiling the query and applying it to the
+collection. T has to be given explicitly: Run[User](query, c).
+*/
+func Run[T any, C Collection[T, C]](query string, c C) (C, error) {
+	q, err := Compile[T](query)
+	if err != nil {
+		return c, err
+	}
+
+	return Apply(q, c), nil
+}
+
+// Returns whether the element satisfies the condition of the query.
+func (q *Query[T]) Match(el T) bool {
+	if q.where == nil {
+		return true
+	}
+
+	return q.where(reflect.ValueOf(&el).Elem())
+}
+
+// Returns whether a sorts before b according to the ORDER BY clause.
+func (q *Query[T]) Less(a, b T) bool {
+	va, vb := reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()
+
+	for _, o := range q.orders {
+		x, okX := o.field.get(va)
+		y, okY := o.field.get(vb)
+
+		var cmp int
+
+		switch {
+		case !okX && !okY:
+			cmp = 0
+		case !okX:
+			cmp = -1
+		case !okY:
+			cmp = 1
+		default:
+			cmp = compareValues(x, y)
+		}
+
+		if o.desc {
+			cmp = -cmp
+		}
+
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+
+	return false
+}
+
+func (q *Query[T]) sort(arr []T) {
+	sort.SliceStable(arr, func(i, j int) bool {
+		return q.Less(arr[i], arr[j])
+	})
+}
+
+type parser struct {
+	tokens []token
+	i      int
+	t      reflect.Type
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokenEOF {
+		p.i++
+	}
+
+	return tok
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	return p.peek().isKeyword(keyword)
+}
+
+/*
+Returns whether the next token starts a field: an identifier, or a
+keyword followed by an operator or a dot, as in Order > 1.
+*/
+func (p *parser) isField() bool {
+	tok, after := p.peek(), p.tokens[p.i]
+	if tok.kind != tokenEOF {
+		after = p.tokens[p.i+1]
+	}
+
+	return tok.kind == tokenIdent ||
+		tok.kind == tokenKeyword && (after.kind == tokenOperator || after.kind == tokenDot)
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.next()
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, unexpected(tok, what)
+	}
+
+	return tok, nil
+}
+
+func unexpected(tok token, what string) error {
+	return &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %v, expected %s", tok, what)}
+}
+
+func parse[T any](p *parser) (*Query[T], error) {
+	q := &Query[T]{limit: -1}
+
+	if p.isField() || !p.isKeyword("ORDER") && !p.isKeyword("LIMIT") && !p.isKeyword("OFFSET") && p.peek().kind != tokenEOF {
+		where, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		q.where = where
+	}
+
+	if p.acceptKeyword("ORDER") {
+		if !p.acceptKeyword("BY") {
+			return nil, unexpected(p.peek(), "BY")
+		}
+
+		for {
+			f, err := p.parseField()
+			if err != nil {
+				return nil, err
+			}
+
+			if !isOrdered(f.t) {
+				return nil, &Error{Pos: f.pos, Msg: fmt.Sprintf("cannot order by field %s of type %v", f.path, f.t)}
+			}
+
+			o := ordering{field: f}
+			if p.acceptKeyword("DESC") {
+				o.desc = true
+			} else {
+				p.acceptKeyword("ASC")
+			}
+
+			q.orders = append(q.orders, o)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+
+			p.next()
+		}
+	}
+
+	if p.acceptKeyword("LIMIT") {
+		n, err := p.parseCount()
+		if err != nil {
+			return nil, err
+		}
+
+		q.limit = n
+	}
+
+	if p.acceptKeyword("OFFSET") {
+		n, err := p.parseCount()
+		if err != nil {
+			return nil, err
+		}
+
+		q.offset = n
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, unexpected(tok, "end of query")
+	}
+
+	return q, nil
+}
+
+func (p *parser) parseCount() (int, error) {
+	tok, err := p.expect(tokenNumber, "a number")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(strings.ReplaceAll(tok.text, "_", ""))
+	if err != nil || n < 0 {
+		return 0, &Error{Pos: tok.pos, Msg: fmt.Sprintf("invalid count %s", tok.text)}
+	}
+
+	return n, nil
+}
+
+func (p *parser) parseOr() (func(v reflect.Value) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(v reflect.Value) bool {
+			return l(v) || right(v)
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (func(v reflect.Value) bool, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(v reflect.Value) bool {
+			return l(v) && right(v)
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (func(v reflect.Value) bool, error) {
+	if !p.isField() && p.acceptKeyword("NOT") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v reflect.Value) bool {
+			return !inner(v)
+		}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		p.next()
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(tokenRParen, "\")\""); err != nil {
+			return nil, err
+		}
+
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (func(v reflect.Value) bool, error) {
+	f, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	negate := p.acceptKeyword("NOT")
+
+	var pred func(v reflect.Value) bool
+
+	switch tok := p.peek(); {
+	case p.acceptKeyword("LIKE"):
+		pred, err = p.parseLike(f)
+	case p.acceptKeyword("IN"):
+		pred, err = p.parseIn(f)
+	case negate:
+		return nil, unexpected(tok, "LIKE or IN")
+	case tok.kind == tokenOperator:
+		p.next()
+
+		var lit token
+		if lit, err = p.parseLiteral(); err == nil {
+			pred, err = compileComparison(f, tok, lit)
+		}
+	default:
+		return nil, unexpected(tok, "an operator")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if negate {
+		inner := pred
+		pred = func(v reflect.Value) bool {
+			return !inner(v)
+		}
+	}
+
+	return pred, nil
+}
+
+func (p *parser) parseLike(f field) (func(v reflect.Value) bool, error) {
+	tok, err := p.expect(tokenString, "a string pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	if f.t.Kind() != reflect.String {
+		return nil, &Error{Pos: f.pos, Msg: fmt.Sprintf("LIKE needs a string field, %s is %v", f.path, f.t)}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("^(?s:")
+	for _, r := range tok.text {
+		switch r {
+		case '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString(")$")
+
+	re := regexp.MustCompile(sb.String())
+
+	return func(v reflect.Value) bool {
+		x, ok := f.get(v)
+		return ok && re.MatchString(x.String())
+	}, nil
+}
+
+func (p *parser) parseIn(f field) (func(v reflect.Value) bool, error) {
+	if _, err := p.expect(tokenLParen, "\"(\""); err != nil {
+		return nil, err
+	}
+
+	eq := token{kind: tokenOperator, text: "="}
+	preds := make([]func(v reflect.Value) bool, 0)
+
+	for {
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+
+		pred, err := compileComparison(f, eq, lit)
+		if err != nil {
+			return nil, err
+		}
+
+		preds = append(preds, pred)
+
+		tok := p.next()
+		if tok.kind == tokenRParen {
+			break
+		} else if tok.kind != tokenComma {
+			return nil, unexpected(tok, "\",\" or \")\"")
+		}
+	}
+
+	return func(v reflect.Value) bool {
+		for _, pred := range preds {
+			if pred(v) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
+
+func (p *parser) parseLiteral() (token, error) {
+	tok := p.next()
+
+	switch {
+	case tok.kind == tokenNumber, tok.kind == tokenString:
+		return tok, nil
+	case tok.isKeyword("TRUE") || tok.isKeyword("FALSE"):
+		return tok, nil
+	}
+
+	return tok, unexpected(tok, "a number, string, TRUE or FALSE")
+}
+
+func compileComparison(f field, op, lit token) (func(v reflect.Value) bool, error) {
+	var compare func(x reflect.Value) int
+	isFloat := f.t.Kind() == reflect.Float32 || f.t.Kind() == reflect.Float64
+
+	typeError := func(kind string) error {
+		return &Error{Pos: lit.pos, Msg: fmt.Sprintf("cannot compare field %s of type %v with %s", f.path, f.t, kind)}
+	}
+
+	switch f.t.Kind() {
+	case reflect.String:
+		if lit.kind != tokenString {
+			return nil, typeError(describe(lit))
+		}
+
+		compare = func(x reflect.Value) int {
+			return strings.Compare(x.String(), lit.text)
+		}
+	case reflect.Bool:
+		if lit.kind != tokenKeyword {
+			return nil, typeError(describe(lit))
+		}
+
+		if op.text != "=" && op.text != "!=" && op.text != "<>" {
+			return nil, &Error{Pos: op.pos, Msg: fmt.Sprintf("operator %s is not defined on %v", op.text, f.t)}
+		}
+
+		b := lit.isKeyword("TRUE")
+		compare = func(x reflect.Value) int {
+			if x.Bool() == b {
+				return 0
+			}
+
+			return 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if lit.kind != tokenNumber {
+			return nil, typeError(describe(lit))
+		}
+
+		n, err := parseNumber(lit)
+		if err != nil {
+			return nil, err
+		}
+
+		compare = func(x reflect.Value) int {
+			return compareNumbers(x, n)
+		}
+	default:
+		return nil, &Error{Pos: f.pos, Msg: fmt.Sprintf("cannot compare field %s of type %v", f.path, f.t)}
+	}
+
+	var test func(cmp int) bool
+
+	switch op.text {
+	case "=":
+		test = func(cmp int) bool { return cmp == 0 }
+	case "!=", "<>":
+		test = func(cmp int) bool { return cmp != 0 }
+	case "<":
+		test = func(cmp int) bool { return cmp < 0 }
+	case "<=":
+		test = func(cmp int) bool { return cmp <= 0 }
+	case ">":
+		test = func(cmp int) bool { return cmp > 0 }
+	case ">=":
+		test = func(cmp int) bool { return cmp >= 0 }
+	default:
+		return nil, &Error{Pos: op.pos, Msg: fmt.Sprintf("unknown operator %s", op.text)}
+	}
+
+	// NaN is unordered: it only satisfies != and <>.
+	isNotEqual := op.text == "!=" || op.text == "<>"
+
+	return func(v reflect.Value) bool {
+		x, ok := f.get(v)
+		if ok && isFloat && math.IsNaN(x.Float()) {
+			return isNotEqual
+		}
+
+		return ok && test(compare(x))
+	}, nil
+}
+
+func describe(lit token) string {
+	switch lit.kind {
+	case tokenNumber:
+		return "number " + lit.text
+	case tokenString:
+		return fmt.Sprintf("string '%s'", lit.text)
+	}
+
+	return strings.ToUpper(lit.text)
+}
+
+type number struct {
+	isInt bool
+	i     int64
+	f     float64
+}
+
+func parseNumber(lit token) (number, error) {
+	text := strings.ReplaceAll(lit.text, "_", "")
+
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return number{isInt: true, i: i, f: float64(i)}, nil
+	}
+
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return number{}, &Error{Pos: lit.pos, Msg: fmt.Sprintf("invalid number %s", lit.text)}
+	}
+
+	return number{f: f}, nil
+}
+
+func compareNumbers(x reflect.Value, n number) int {
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n.isInt {
+			return compareOrdered(x.Int(), n.i)
+		}
+
+		return compareOrdered(float64(x.Int()), n.f)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n.isInt {
+			if n.i < 0 {
+				return 1
+			}
+
+			return compareOrdered(x.Uint(), uint64(n.i))
+		}
+
+		return compareOrdered(float64(x.Uint()), n.f)
+	}
+
+	return compareOrdered(x.Float(), n.f)
+}
+
+func compareValues(x, y reflect.Value) int {
+	switch x.Kind() {
+	case reflect.String:
+		return strings.Compare(x.String(), y.String())
+	case reflect.Bool:
+		switch {
+		case x.Bool() == y.Bool():
+			return 0
+		case y.Bool():
+			return -1
+		}
+
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(x.Uint(), y.Uint())
+	}
+
+	// NaN sorts first, as missing values do, so that the order stays consistent.
+	a, b := x.Float(), y.Float()
+
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	}
+
+	return compareOrdered(a, b)
+}
+
+func compareOrdered[T int64 | uint64 | float64](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+func isOrdered(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
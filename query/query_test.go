@@ -0,0 +1,286 @@
+package query_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kdl-dev/gofunc"
+	"github.com/kdl-dev/gofunc/query"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string
+}
+
+type user struct {
+	Name    string
+	Age     int
+	Score   float64
+	Active  bool
+	Level   uint8 `json:"level"`
+	Address *address
+}
+
+var users = []user{
+	{"Kate", 25, 9.5, true, 3, &address{"Paris"}},
+	{"John", 17, 7, false, 1, &address{"Berlin"}},
+	{"Kim", 31, 8.25, true, 2, nil},
+	{"Sam", 18, 6.5, false, 2, &address{"Paris"}},
+	{"Karl", 45, 9.5, true, 5, &address{"Rome"}},
+}
+
+func names(c interface{ ToSlice() []user }) []string {
+	result := make([]string, 0)
+	for _, el := range c.ToSlice() {
+		result = append(result, el.Name)
+	}
+
+	return result
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "test1",
+			query:    "Age >= 18 AND Name LIKE 'K%' ORDER BY Age DESC LIMIT 10",
+			expected: []string{"Karl", "Kim", "Kate"},
+		},
+		{
+			name:     "test2",
+			query:    "",
+			expected: []string{"Kate", "John", "Kim", "Sam", "Karl"},
+		},
+		{
+			name:     "test3",
+			query:    "order by Score desc, Name",
+			expected: []string{"Karl", "Kate", "Kim", "John", "Sam"},
+		},
+		{
+			name:     "test4",
+			query:    "Active = false OR Score > 9 ORDER BY Name LIMIT 2 OFFSET 1",
+			expected: []string{"Karl", "Kate"},
+		},
+		{
+			name:     "test5",
+			query:    "NOT (Age < 20 OR Active = TRUE)",
+			expected: []string{},
+		},
+		{
+			name:     "test6",
+			query:    "Address.City IN ('Paris', 'Rome') AND level != 2",
+			expected: []string{"Kate", "Karl"},
+		},
+		{
+			name:     "test7",
+			query:    "Name NOT LIKE 'K_m' AND name NOT IN ('John') ORDER BY Address.City",
+			expected: []string{"Kate", "Sam", "Karl"},
+		},
+		{
+			name:     "test8",
+			query:    "Score <= 7.5 ORDER BY Score",
+			expected: []string{"Sam", "John"},
+		},
+		{
+			name:     "test9",
+			query:    "Level > -1 AND Age <> 25.5 LIMIT 0",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		result, err := query.Run[user](test.query, gofunc.New(users))
+		require.NoError(t, err)
+		require.Equal(t, test.expected, names(result))
+	}
+}
+
+func TestNaN(t *testing.T) {
+	rows := []user{
+		{"Kate", 25, 9.5, true, 3, nil},
+		{"John", 17, math.NaN(), false, 1, nil},
+		{"Kim", 31, 5, true, 2, nil},
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"Score = 5", []string{"Kim"}},
+		{"Score != 5", []string{"Kate", "John"}},
+		{"Score <> 5", []string{"Kate", "John"}},
+		{"Score < 100", []string{"Kate", "Kim"}},
+		{"Score <= 100", []string{"Kate", "Kim"}},
+		{"Score > 0", []string{"Kate", "Kim"}},
+		{"Score >= 0", []string{"Kate", "Kim"}},
+		{"Score IN (5, 9.5)", []string{"Kate", "Kim"}},
+		{"Score NOT IN (5, 9.5)", []string{"John"}},
+		{"ORDER BY Score", []string{"John", "Kim", "Kate"}},
+		{"ORDER BY Score DESC", []string{"Kate", "Kim", "John"}},
+	}
+
+	for _, test := range tests {
+		t.Log(test.query)
+
+		result, err := query.Run[user](test.query, gofunc.New(rows))
+		require.NoError(t, err)
+		require.Equal(t, test.expected, names(result))
+	}
+}
+
+type setting struct {
+	Order  int
+	Limit  int
+	Desc   string
+	In     uint
+	Not    bool
+	Offset *setting
+}
+
+func TestKeywordFields(t *testing.T) {
+	rows := []setting{
+		{1, 10, "a", 1, true, nil},
+		{2, 20, "b", 2, false, &setting{Limit: 5}},
+		{3, 30, "c", 3, true, &setting{Limit: 50}},
+	}
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"Order > 1", []int{2, 3}},
+		{"NOT Order > 1", []int{1}},
+		{"Not = true ORDER BY Order DESC", []int{3, 1}},
+		{"Limit >= 20 AND Desc != 'c'", []int{2}},
+		{"In IN (1, 3) ORDER BY Desc DESC LIMIT 1", []int{3}},
+		{`"Order" IN (2, 3) AND "Not" = false`, []int{2}},
+		{"Offset.Limit > 10", []int{3}},
+		{"ORDER BY Limit DESC LIMIT 2 OFFSET 1", []int{2, 1}},
+	}
+
+	for _, test := range tests {
+		t.Log(test.query)
+
+		result, err := query.Run[setting](test.query, gofunc.New(rows))
+		require.NoError(t, err)
+
+		orders := make([]int, 0)
+		for _, el := range result.ToSlice() {
+			orders = append(orders, el.Order)
+		}
+
+		require.Equal(t, test.expected, orders)
+	}
+
+	_, err := query.Compile[setting](`"Order > 1`)
+	require.EqualError(t, err, "query: position 1: unterminated quoted field name")
+}
+
+func TestCompile(t *testing.T) {
+	q := query.MustCompile[*user]("Address.City = 'Paris' ORDER BY Age")
+
+	require.True(t, q.Match(&users[0]))
+	require.False(t, q.Match(&users[1]))
+	require.False(t, q.Match(&users[2]))
+	require.True(t, q.Less(&users[1], &users[0]))
+
+	result := query.Apply(q, gofunc.New([]*user{&users[0], &users[3], &users[1]})).ToSlice()
+	require.Equal(t, []*user{&users[3], &users[0]}, result)
+
+	_, err := query.Compile[int]("Age > 1")
+	require.Error(t, err)
+}
+
+func TestCompileError(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+		pos      int
+	}{
+		{
+			name:     "test1",
+			query:    "Age >= 18 AND Nmae LIKE 'K%'",
+			expected: "query: position 15: query_test.user has no field Nmae",
+			pos:      14,
+		},
+		{
+			name:     "test2",
+			query:    "Age >= '18'",
+			expected: "query: position 8: cannot compare field Age of type int with string '18'",
+			pos:      7,
+		},
+		{
+			name:     "test3",
+			query:    "Age LIKE 'K%'",
+			expected: "query: position 1: LIKE needs a string field, Age is int",
+			pos:      0,
+		},
+		{
+			name:     "test4",
+			query:    "Name = 'Kate",
+			expected: "query: position 8: unterminated string",
+			pos:      7,
+		},
+		{
+			name:     "test5",
+			query:    "Age > 1 ORDER Age",
+			expected: "query: position 15: unexpected \"Age\", expected BY",
+			pos:      14,
+		},
+		{
+			name:     "test6",
+			query:    "(Age > 1",
+			expected: "query: position 9: unexpected end of query, expected \")\"",
+			pos:      8,
+		},
+		{
+			name:     "test7",
+			query:    "Active < TRUE",
+			expected: "query: position 8: operator < is not defined on bool",
+			pos:      7,
+		},
+		{
+			name:     "test8",
+			query:    "Name.First = 'K'",
+			expected: "query: position 6: Name is not a struct",
+			pos:      5,
+		},
+		{
+			name:     "test9",
+			query:    "ORDER BY Address",
+			expected: "query: position 10: cannot order by field Address of type query_test.address",
+			pos:      9,
+		},
+		{
+			name:     "test10",
+			query:    "Age > 1 LIMIT -1",
+			expected: "query: position 15: invalid count -1",
+			pos:      14,
+		},
+		{
+			name:     "test11",
+			query:    "Age > 1 Name",
+			expected: "query: position 9: unexpected \"Name\", expected end of query",
+			pos:      8,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		_, err := query.Compile[user](test.query)
+		require.EqualError(t, err, test.expected)
+
+		var queryErr *query.Error
+		require.True(t, errors.As(err, &queryErr))
+		require.Equal(t, test.pos, queryErr.Pos)
+	}
+}
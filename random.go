@@ -0,0 +1,144 @@
+package gofunc
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+/*
+Returns a collection consisting of the elements of this collection
+in random order. The order is fully determined by rng; if rng is
+nil, a generator seeded with the current time is used.
+*/
+func (c *collection[T]) Shuffle(rng *rand.Rand) *collection[T] {
+	rng = randomizer(rng)
+	newcollection := New[T](c.data)
+
+	rng.Shuffle(len(newcollection.data), func(i, j int) {
+		newcollection.data[i], newcollection.data[j] = newcollection.data[j], newcollection.data[i]
+	})
+
+	return newcollection
+}
+
+/*
+Returns a collection of n elements picked at random from this
+collection without replacement. If n exceeds the length of the
+collection, all of its elements are returned in random order.
+*/
+func (c *collection[T]) Sample(n int, rng *rand.Rand) *collection[T] {
+	if n > c.Len() {
+		n = c.Len()
+	} else if n < 0 {
+		n = 0
+	}
+
+	rng = randomizer(rng)
+	newcollection := New[T](c.data)
+
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(len(newcollection.data)-i)
+		newcollection.data[i], newcollection.data[j] = newcollection.data[j], newcollection.data[i]
+	}
+
+	newcollection.data = newcollection.data[:n]
+
+	return newcollection
+}
+
+/*
+Returns a collection of n elements picked at random from
+this collection with replacement, so an element may be
+picked several times.
+*/
+func (c *collection[T]) SampleWithReplacement(n int, rng *rand.Rand) *collection[T] {
+	if n < 0 || c.Len() == 0 {
+		n = 0
+	}
+
+	rng = randomizer(rng)
+	newcollection := New[T](make([]T, n))
+
+	for i := 0; i < n; i++ {
+		newcollection.data[i] = c.data[rng.Intn(len(c.data))]
+	}
+
+	return newcollection
+}
+
+/*
+Returns a collection of n elements picked at random from this
+collection without replacement, where the chance of an element
+to be picked is proportional to its weight. Elements with a
+weight that is not positive are never picked.
+*/
+func (c *collection[T]) SampleWeighted(n int, weight func(el T) float64, rng *rand.Rand) *collection[T] {
+	if weight == nil || n <= 0 {
+		return New(make([]T, 0))
+	}
+
+	rng = randomizer(rng)
+	keyed := make([]Pair[T, float64], 0, len(c.data))
+
+	for _, value := range c.data {
+		w := weight(value)
+		if w <= 0 || math.IsNaN(w) {
+			continue
+		}
+
+		keyed = append(keyed, Pair[T, float64]{value, math.Pow(rng.Float64(), 1/w)})
+	}
+
+	sort.SliceStable(keyed, func(i, j int) bool {
+		return keyed[i].Second > keyed[j].Second
+	})
+
+	if n > len(keyed) {
+		n = len(keyed)
+	}
+
+	newcollection := New[T](make([]T, n))
+
+	for i := 0; i < n; i++ {
+		newcollection.data[i] = keyed[i].First
+	}
+
+	return newcollection
+}
+
+/*
+Drains the source channel and returns a collection of at most n
+elements picked uniformly at random from everything received,
+keeping no more than n elements in memory at any time.
+*/
+func ReservoirSample[T comparable](source <-chan T, n int, rng *rand.Rand) *collection[T] {
+	if n < 0 {
+		n = 0
+	}
+
+	rng = randomizer(rng)
+	newcollection := New[T](make([]T, 0, n))
+	seen := 0
+
+	for value := range source {
+		seen++
+
+		if len(newcollection.data) < n {
+			newcollection.data = append(newcollection.data, value)
+		} else if j := rng.Intn(seen); j < n {
+			newcollection.data[j] = value
+		}
+	}
+
+	return newcollection
+}
+
+func randomizer(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return rng
+}
@@ -0,0 +1,137 @@
+package gofunc
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShuffle(t *testing.T) {
+	input := New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	first := input.Shuffle(rand.New(rand.NewSource(42)))
+	second := input.Shuffle(rand.New(rand.NewSource(42)))
+	require.Equal(t, first, second)
+	require.Equal(t, New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), input)
+
+	sorted := first.Sort(func(arr []int) { sort.Ints(arr) })
+	require.Equal(t, input, sorted)
+}
+
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		n        int
+		expected int
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}),
+			n:        4,
+			expected: 4,
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			n:        10,
+			expected: 3,
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3}),
+			n:        -1,
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.Sample(test.n, rand.New(rand.NewSource(1)))
+		require.Equal(t, test.expected, result.Len())
+		require.Equal(t, result.Len(), result.Distinct().Len())
+		require.Subset(t, test.input.ToSlice(), result.ToSlice())
+		require.Equal(t, result, test.input.Sample(test.n, rand.New(rand.NewSource(1))))
+	}
+}
+
+func TestSampleWithReplacement(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		n        int
+		expected int
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2}),
+			n:        10,
+			expected: 10,
+		},
+		{
+			name:     "test2",
+			input:    New([]int{}),
+			n:        10,
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.SampleWithReplacement(test.n, rand.New(rand.NewSource(1)))
+		require.Equal(t, test.expected, result.Len())
+		require.Subset(t, test.input.ToSlice(), result.ToSlice())
+	}
+}
+
+func TestSampleWeighted(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		n        int
+		weight   func(int) float64
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4}),
+			n:        2,
+			weight:   func(el int) float64 { return float64(el % 2) },
+			expected: New([]int{1, 3}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3, 4}),
+			n:        2,
+			weight:   nil,
+			expected: New([]int{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.
+			SampleWeighted(test.n, test.weight, rand.New(rand.NewSource(1))).
+			Sort(func(arr []int) { sort.Ints(arr) })
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestReservoirSample(t *testing.T) {
+	source := make(chan int)
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			source <- i
+		}
+
+		close(source)
+	}()
+
+	result := ReservoirSample(source, 10, rand.New(rand.NewSource(1)))
+	require.Equal(t, 10, result.Len())
+	require.Equal(t, 10, result.Distinct().Len())
+	require.True(t, result.AllMatch(func(el int) bool { return el >= 0 && el < 1000 }))
+}
@@ -0,0 +1,128 @@
+package gofunc
+
+/*
+Returns a collection consisting of the elements of this collection
+rotated to the left by k positions. A negative k rotates to the right.
+*/
+func (c *collection[T]) Rotate(k int) *collection[T] {
+	if len(c.data) == 0 {
+		return New(c.data)
+	}
+
+	k %= len(c.data)
+	if k < 0 {
+		k += len(c.data)
+	}
+
+	newcollection := New[T](make([]T, 0, len(c.data)))
+	newcollection.data = append(newcollection.data, c.data[k:]...)
+	newcollection.data = append(newcollection.data, c.data[:k]...)
+
+	return newcollection
+}
+
+/*
+Returns a collection consisting of the elements of this collection
+and the other collections merged in round-robin order. When a
+collection runs out of elements, the rest keep alternating.
+*/
+func (c *collection[T]) Interleave(others ...*collection[T]) *collection[T] {
+	sources := make([][]T, 0, len(others)+1)
+	sources = append(sources, c.data)
+	size, longest := len(c.data), len(c.data)
+
+	for _, other := range others {
+		if other == nil {
+			continue
+		}
+
+		sources = append(sources, other.data)
+		size += len(other.data)
+
+		if len(other.data) > longest {
+			longest = len(other.data)
+		}
+	}
+
+	newcollection := New[T](make([]T, 0, size))
+
+	for i := 0; i < longest; i++ {
+		for _, source := range sources {
+			if i < len(source) {
+				newcollection.data = append(newcollection.data, source[i])
+			}
+		}
+	}
+
+	return newcollection
+}
+
+/*
+Returns a collection consisting of the elements
+of this collection separated by the given separator.
+*/
+func (c *collection[T]) Intersperse(separator T) *collection[T] {
+	if len(c.data) == 0 {
+		return New(c.data)
+	}
+
+	newcollection := New[T](make([]T, 0, len(c.data)*2-1))
+
+	for i, value := range c.data {
+		if i > 0 {
+			newcollection.data = append(newcollection.data, separator)
+		}
+
+		newcollection.data = append(newcollection.data, value)
+	}
+
+	return newcollection
+}
+
+/*
+Returns a collection with the elements at indexes i and j swapped.
+If either index is out of range, the collection is returned unchanged.
+*/
+func (c *collection[T]) Swap(i, j int) *collection[T] {
+	newcollection := New[T](c.data)
+
+	if i < 0 || i >= len(c.data) || j < 0 || j >= len(c.data) {
+		return newcollection
+	}
+
+	newcollection.data[i], newcollection.data[j] = newcollection.data[j], newcollection.data[i]
+
+	return newcollection
+}
+
+/*
+Returns the transposition of the given collections as a collection
+of collections: the i-th collection of the result consists of the
+i-th elements of the input collections. Collections too short to
+have an i-th element are skipped.
+*/
+func Transpose[T comparable](rows ...*collection[T]) *collection[*collection[T]] {
+	var longest int
+
+	for _, row := range rows {
+		if row != nil && len(row.data) > longest {
+			longest = len(row.data)
+		}
+	}
+
+	newcollection := New[*collection[T]](make([]*collection[T], longest))
+
+	for i := 0; i < longest; i++ {
+		column := New[T](make([]T, 0, len(rows)))
+
+		for _, row := range rows {
+			if row != nil && i < len(row.data) {
+				column.data = append(column.data, row.data[i])
+			}
+		}
+
+		newcollection.data[i] = column
+	}
+
+	return newcollection
+}
@@ -0,0 +1,173 @@
+package gofunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		k        int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			k:        2,
+			expected: New([]int{3, 4, 5, 1, 2}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			k:        -1,
+			expected: New([]int{5, 1, 2, 3, 4}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3}),
+			k:        7,
+			expected: New([]int{2, 3, 1}),
+		},
+		{
+			name:     "test4",
+			input:    New([]int{}),
+			k:        3,
+			expected: New([]int{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.Rotate(test.k)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		others   []*collection[int]
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			others:   []*collection[int]{New([]int{10, 20, 30})},
+			expected: New([]int{1, 10, 2, 20, 3, 30}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1}),
+			others:   []*collection[int]{New([]int{10, 20, 30}), New([]int{100, 200})},
+			expected: New([]int{1, 10, 100, 20, 200, 30}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2}),
+			others:   nil,
+			expected: New([]int{1, 2}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.Interleave(test.others...)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestIntersperse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			expected: New([]int{1, 0, 2, 0, 3}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1}),
+			expected: New([]int{1}),
+		},
+		{
+			name:     "test3",
+			input:    New([]int{}),
+			expected: New([]int{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.Intersperse(0)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		i, j     int
+		expected *collection[int]
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			i:        0,
+			j:        2,
+			expected: New([]int{3, 2, 1}),
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			i:        0,
+			j:        3,
+			expected: New([]int{1, 2, 3}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection := test.input.Swap(test.i, test.j)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []*collection[int]
+		expected [][]int
+	}{
+		{
+			name:     "test1",
+			input:    []*collection[int]{New([]int{1, 2, 3}), New([]int{4, 5, 6})},
+			expected: [][]int{{1, 4}, {2, 5}, {3, 6}},
+		},
+		{
+			name:     "test2",
+			input:    []*collection[int]{New([]int{1, 2}), New([]int{3}), nil},
+			expected: [][]int{{1, 3}, {2}},
+		},
+		{
+			name:     "test3",
+			input:    nil,
+			expected: [][]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		transposed := Transpose(test.input...)
+		result := make([][]int, 0, transposed.Len())
+		transposed.ForEach(func(el *collection[int]) { result = append(result, el.ToSlice()) })
+		require.Equal(t, test.expected, result)
+	}
+}
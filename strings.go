@@ -0,0 +1,103 @@
+package gofunc
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StringOptions describes how ToStringWith and WriteStringTo turn a collection into text.
+type StringOptions[T comparable] struct {
+	// Convert turns an element into text. If nil, the default fmt format is used.
+	Convert func(el T) string
+	// Separator is written between the elements.
+	Separator string
+	// Prefix and Suffix are written before and after the elements.
+	Prefix, Suffix string
+	// MaxElements limits the number of elements written, if positive.
+	MaxElements int
+	// Truncated is written in place of the elements left out by MaxElements.
+	Truncated string
+}
+
+/*
+Converts a collection to a string, with the elements separated by sep.
+If convert is nil, the elements are written in their default fmt format.
+*/
+func (c *collection[T]) Join(sep string, convert func(el T) string) string {
+	return c.ToStringWith(StringOptions[T]{Convert: convert, Separator: sep})
+}
+
+/*
+Converts a collection to a string according to the given options.
+*/
+func (c *collection[T]) ToStringWith(opts StringOptions[T]) string {
+	var resultStr strings.Builder
+
+	// Writing into a strings.Builder never fails.
+	_, _ = c.WriteStringTo(&resultStr, opts)
+
+	return resultStr.String()
+}
+
+/*
+Writes a collection as text to w according to the given options,
+without building the whole string in memory first. Returns the
+number of bytes written.
+*/
+func (c *collection[T]) WriteStringTo(w io.Writer, opts StringOptions[T]) (int, error) {
+	convert := opts.Convert
+	if convert == nil {
+		convert = func(el T) string { return fmt.Sprint(el) }
+	}
+
+	elements := c.data
+	isTruncated := opts.MaxElements > 0 && len(elements) > opts.MaxElements
+
+	if isTruncated {
+		elements = elements[:opts.MaxElements]
+	}
+
+	var total int
+
+	write := func(s string) error {
+		n, err := io.WriteString(w, s)
+		total += n
+
+		return err
+	}
+
+	if err := write(opts.Prefix); err != nil {
+		return total, err
+	}
+
+	for i, value := range elements {
+		if i > 0 {
+			if err := write(opts.Separator); err != nil {
+				return total, err
+			}
+		}
+
+		if err := write(convert(value)); err != nil {
+			return total, err
+		}
+	}
+
+	if isTruncated && opts.Truncated != "" {
+		if len(elements) > 0 {
+			if err := write(opts.Separator); err != nil {
+				return total, err
+			}
+		}
+
+		if err := write(opts.Truncated); err != nil {
+			return total, err
+		}
+	}
+
+	if err := write(opts.Suffix); err != nil {
+		return total, err
+	}
+
+	return total, nil
+}
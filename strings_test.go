@@ -0,0 +1,133 @@
+package gofunc
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		sep      string
+		script   func(int) string
+		expected string
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			sep:      ", ",
+			script:   func(el int) string { return "#" + strconv.Itoa(el) },
+			expected: "#1, #2, #3",
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3}),
+			sep:      " ",
+			script:   nil,
+			expected: "1 2 3",
+		},
+		{
+			name:     "test3",
+			input:    New([]int{}),
+			sep:      ", ",
+			script:   nil,
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.Join(test.sep, test.script)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+func TestToStringWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		opts     StringOptions[int]
+		expected string
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			opts:     StringOptions[int]{Separator: " ", Prefix: "[", Suffix: "]"},
+			expected: "[1 2 3]",
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			opts:     StringOptions[int]{Separator: ", ", MaxElements: 2, Truncated: "..."},
+			expected: "1, 2, ...",
+		},
+		{
+			name:     "test3",
+			input:    New([]int{1, 2, 3, 4, 5}),
+			opts:     StringOptions[int]{Separator: ",", MaxElements: 3},
+			expected: "1,2,3",
+		},
+		{
+			name:     "test4",
+			input:    New([]int{1, 2}),
+			opts:     StringOptions[int]{Separator: ",", MaxElements: 2, Truncated: "..."},
+			expected: "1,2",
+		},
+		{
+			name:     "test5",
+			input:    New([]int{}),
+			opts:     StringOptions[int]{Prefix: "{", Suffix: "}"},
+			expected: "{}",
+		},
+		{
+			name:  "test6",
+			input: New([]int{10, 11}),
+			opts: StringOptions[int]{
+				Convert:   func(el int) string { return strconv.FormatInt(int64(el), 16) },
+				Separator: "|",
+			},
+			expected: "a|b",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		result := test.input.ToStringWith(test.opts)
+		require.Equal(t, test.expected, result)
+	}
+}
+
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+
+		return n, errors.New("disk full")
+	}
+
+	w.limit -= len(p)
+
+	return len(p), nil
+}
+
+func TestWriteStringTo(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := New([]int{1, 2, 3}).WriteStringTo(&buf, StringOptions[int]{Separator: "\n", Suffix: "\n"})
+	require.NoError(t, err)
+	require.Equal(t, "1\n2\n3\n", buf.String())
+	require.Equal(t, 6, n)
+
+	n, err = New([]int{1, 2, 3}).WriteStringTo(&failingWriter{limit: 2}, StringOptions[int]{Separator: ","})
+	require.Error(t, err)
+	require.Equal(t, 2, n)
+}
@@ -0,0 +1,124 @@
+package gofunc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+/*
+Returns a collection of the lines read from r, without their line
+endings. Lines longer than bufio.MaxScanTokenSize produce an error;
+use FromScanner with a larger buffer to read them. All the lines are
+held in memory; use ScanTokens to stream inputs too large for that.
+*/
+func FromLines(r io.Reader) (*collection[string], error) {
+	return FromScanner(bufio.NewScanner(r))
+}
+
+/*
+Returns a collection of the tokens produced by the scanner, so any
+split function (bufio.ScanWords, bufio.ScanRunes, SplitOn, ...) can
+be used to break the input up. The input is read incrementally, but
+all the tokens are held in memory; use ScanTokens to stream them.
+*/
+func FromScanner(scanner *bufio.Scanner) (*collection[string], error) {
+	newcollection := New(make([]string, 0))
+
+	if scanner == nil {
+		return newcollection, nil
+	}
+
+	for scanner.Scan() {
+		newcollection.data = append(newcollection.data, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newcollection, nil
+}
+
+/*
+Returns a channel that receives the tokens produced by the scanner
+one at a time, so large inputs can be processed without being loaded
+in memory, for example by ReservoirSample. The channel is closed when
+the input ends or ctx is done; scanner.Err reports the failure, if any,
+afterwards. A consumer that stops early cancels ctx to release the
+reading goroutine, which then exits after the token being read.
+*/
+func ScanTokens(ctx context.Context, scanner *bufio.Scanner) <-chan string {
+	tokens := make(chan string)
+
+	go func() {
+		defer close(tokens)
+
+		if scanner == nil {
+			return
+		}
+
+		for scanner.Scan() {
+			select {
+			case tokens <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return tokens
+}
+
+/*
+Returns a split function for bufio.Scanner that breaks
+the input up at every occurrence of the delimiter.
+*/
+func SplitOn(delimiter string) bufio.SplitFunc {
+	delim := []byte(delimiter)
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		if len(delim) > 0 {
+			if i := bytes.Index(data, delim); i >= 0 {
+				return i + len(delim), data[:i], nil
+			}
+		}
+
+		if atEOF {
+			return len(data), data, nil
+		}
+
+		return 0, nil, nil
+	}
+}
+
+/*
+Writes the elements of this collection to w, one per line, using the
+format function to turn each element into text. If format is nil,
+the elements are written in their default fmt format.
+*/
+func (c *collection[T]) WriteLines(w io.Writer, format func(el T) string) error {
+	if format == nil {
+		format = func(el T) string { return fmt.Sprint(el) }
+	}
+
+	writer := bufio.NewWriter(w)
+
+	for _, value := range c.data {
+		if _, err := writer.WriteString(format(value)); err != nil {
+			return err
+		}
+
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,140 @@
+package gofunc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *collection[string]
+	}{
+		{
+			name:     "test1",
+			input:    "INFO start\r\nWARN disk\nINFO stop\n",
+			expected: New([]string{"INFO start", "WARN disk", "INFO stop"}),
+		},
+		{
+			name:     "test2",
+			input:    "",
+			expected: New([]string{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		collection, err := FromLines(strings.NewReader(test.input))
+		require.NoError(t, err)
+		require.Equal(t, test.expected, collection)
+	}
+
+	_, err := FromLines(strings.NewReader(strings.Repeat("x", bufio.MaxScanTokenSize+1)))
+	require.ErrorIs(t, err, bufio.ErrTooLong)
+}
+
+func TestFromScanner(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		split    bufio.SplitFunc
+		expected *collection[string]
+	}{
+		{
+			name:     "test1",
+			input:    "one  two\nthree",
+			split:    bufio.ScanWords,
+			expected: New([]string{"one", "two", "three"}),
+		},
+		{
+			name:     "test2",
+			input:    "añb",
+			split:    bufio.ScanRunes,
+			expected: New([]string{"a", "ñ", "b"}),
+		},
+		{
+			name:     "test3",
+			input:    "a;;b;;;c",
+			split:    SplitOn(";;"),
+			expected: New([]string{"a", "b", ";c"}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		scanner := bufio.NewScanner(strings.NewReader(test.input))
+		scanner.Split(test.split)
+		collection, err := FromScanner(scanner)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, collection)
+	}
+}
+
+func TestScanTokens(t *testing.T) {
+	var input strings.Builder
+
+	for i := 0; i < 100; i++ {
+		input.WriteString(strconv.Itoa(i) + "\n")
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(input.String()))
+	sample := ReservoirSample(ScanTokens(context.Background(), scanner), 5, rand.New(rand.NewSource(1)))
+	require.NoError(t, scanner.Err())
+	require.Equal(t, 5, sample.Distinct().Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tokens := ScanTokens(ctx, bufio.NewScanner(strings.NewReader(input.String())))
+
+	require.Equal(t, "0", <-tokens)
+	require.Equal(t, "1", <-tokens)
+	cancel()
+
+	for range tokens {
+	}
+
+	_, isOpen := <-tokens
+	require.False(t, isOpen)
+}
+
+func TestWriteLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *collection[int]
+		format   func(int) string
+		expected string
+	}{
+		{
+			name:     "test1",
+			input:    New([]int{1, 2, 3}),
+			format:   func(el int) string { return "n=" + strconv.Itoa(el) },
+			expected: "n=1\nn=2\nn=3\n",
+		},
+		{
+			name:     "test2",
+			input:    New([]int{1, 2}),
+			format:   nil,
+			expected: "1\n2\n",
+		},
+		{
+			name:     "test3",
+			input:    New([]int{}),
+			format:   nil,
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		var buf bytes.Buffer
+		require.NoError(t, test.input.WriteLines(&buf, test.format))
+		require.Equal(t, test.expected, buf.String())
+	}
+}